@@ -0,0 +1,189 @@
+package urip
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Result is the result of parsing one of the inputs of ParseAll.
+type Result struct {
+	Index int // the order in which the input was received
+	Data  []byte
+	Uri   *Uri
+	Err   error
+}
+
+// BatchStats counts the results of BatchParser.ParseAll.
+type BatchStats struct {
+	Parsed int
+	Failed int
+	// Errors counts the failed inputs by the error, which tells the rule
+	// that could not be found, e.g. "scheme not found.", or
+	// "data following URI found." if the input is not a URI as a whole.
+	Errors map[string]int
+}
+
+// inFlightPerWorker is the number of inputs per worker which may be parsed
+// but not sent yet. It bounds the results which wait for an earlier input in
+// the ordered mode.
+const inFlightPerWorker = 16
+
+// BatchParser parses URIs on multiple goroutines. The statistics are of the
+// last call of ParseAll, so ParseAll of a BatchParser must not be called
+// while the results of the previous call are being sent.
+type BatchParser struct {
+	// Workers is the number of goroutines. If it is 0 or less,
+	// runtime.GOMAXPROCS(0) is used.
+	Workers int
+	// Ordered makes ParseAll send the results in the order of the inputs.
+	Ordered bool
+
+	mutex sync.Mutex
+	stats BatchStats
+}
+
+// ParseAll parses the inputs with workers goroutines, and sends the results
+// as soon as they are parsed, not in the order of the inputs. Use
+// BatchParser to keep the order or to get the statistics.
+func ParseAll(ctx context.Context, inputs <-chan []byte, workers int) <-chan Result {
+	parser := &BatchParser{Workers: workers}
+	return parser.ParseAll(ctx, inputs)
+}
+
+// ParseAll parses each input as a URI, and sends the results to the
+// returned channel. The channel is closed when inputs is closed and all of
+// them are sent, or when ctx is done. An input fails if it has bytes
+// following the URI.
+//
+// At most inFlightPerWorker inputs per worker are read ahead of the results
+// sent, so a slow consumer or, in the ordered mode, a slow input does not
+// make the pending results grow without bound.
+//
+// Each Result.Uri refers to its Result.Data, so the input must not be
+// modified after it is sent.
+func (parser *BatchParser) ParseAll(ctx context.Context, inputs <-chan []byte) <-chan Result {
+	workers := parser.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	parser.mutex.Lock()
+	parser.stats = BatchStats{Errors: map[string]int{}}
+	parser.mutex.Unlock()
+
+	jobs := make(chan Result)
+	results := make(chan Result)
+	output := make(chan Result)
+	inFlight := make(chan struct{}, workers*inFlightPerWorker)
+
+	// Number the inputs.
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			var data []byte
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok = <-inputs:
+				if !ok {
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case inFlight <- struct{}{}:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- Result{Index: index, Data: data}:
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.Uri, job.Err = parseEntire(job.Data)
+				select {
+				case <-ctx.Done():
+					return
+				case results <- job:
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	go func() {
+		defer close(output)
+		// pending keeps the results which came before the results of the
+		// earlier inputs.
+		pending := map[int]Result{}
+		next := 0
+		for result := range results {
+			parser.count(result)
+			if !parser.Ordered {
+				if !send(ctx, output, result) {
+					return
+				}
+				<-inFlight
+				continue
+			}
+			pending[result.Index] = result
+			for {
+				result, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if !send(ctx, output, result) {
+					return
+				}
+				<-inFlight
+			}
+		}
+	}()
+	return output
+}
+
+// Stats returns the statistics of the results sent so far by ParseAll.
+func (parser *BatchParser) Stats() BatchStats {
+	parser.mutex.Lock()
+	defer parser.mutex.Unlock()
+	stats := parser.stats
+	stats.Errors = map[string]int{}
+	for err, count := range parser.stats.Errors {
+		stats.Errors[err] = count
+	}
+	return stats
+}
+
+func (parser *BatchParser) count(result Result) {
+	parser.mutex.Lock()
+	defer parser.mutex.Unlock()
+	if result.Err != nil {
+		parser.stats.Failed++
+		parser.stats.Errors[result.Err.Error()]++
+	} else {
+		parser.stats.Parsed++
+	}
+}
+
+func send(ctx context.Context, output chan<- Result, result Result) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case output <- result:
+		return true
+	}
+}
@@ -0,0 +1,164 @@
+package urip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func batchInputs(data []string) <-chan []byte {
+	inputs := make(chan []byte)
+	go func() {
+		defer close(inputs)
+		for _, d := range data {
+			inputs <- []byte(d)
+		}
+	}()
+	return inputs
+}
+
+func TestBatchParserOrdered(t *testing.T) {
+	data := []string{}
+	for i := 0; i < 1000; i++ {
+		switch i % 4 {
+		case 0:
+			data = append(data, fmt.Sprintf("http://example.com/%v", i))
+		case 1:
+			data = append(data, fmt.Sprintf("%v", i))
+		case 2:
+			data = append(data, fmt.Sprintf("mailto:user%v@example.com", i))
+		case 3:
+			data = append(data, fmt.Sprintf("a%v", i))
+		}
+	}
+	parser := &BatchParser{Workers: 8, Ordered: true}
+	index := 0
+	for result := range parser.ParseAll(context.Background(), batchInputs(data)) {
+		equals("Index", t, index, result.Index)
+		equals("Data", t, data[index], string(result.Data))
+		if index%2 == 0 {
+			if result.Err != nil {
+				t.Errorf("%s: %v", result.Data, result.Err)
+				continue
+			}
+			equals("Uri", t, data[index], result.Uri.String())
+		} else if result.Err == nil {
+			t.Errorf("%s: error expected", result.Data)
+		}
+		index++
+	}
+	equals("results", t, len(data), index)
+
+	stats := parser.Stats()
+	equals("Parsed", t, 500, stats.Parsed)
+	equals("Failed", t, 500, stats.Failed)
+	equals("Errors", t, fmt.Sprint(map[string]int{
+		"scheme not found.":                 250,
+		"colon following scheme not found.": 250,
+	}), fmt.Sprint(stats.Errors))
+}
+
+func TestBatchParserTrailingData(t *testing.T) {
+	data := []string{"http://exa mple.com", "http://example.com"}
+	parser := &BatchParser{Workers: 2, Ordered: true}
+	results := []Result{}
+	for result := range parser.ParseAll(context.Background(), batchInputs(data)) {
+		results = append(results, result)
+	}
+	equals("results", t, 2, len(results))
+	equals("Err", t, fmt.Sprint(errTrailingData), fmt.Sprint(results[0].Err))
+	equals("Err", t, fmt.Sprint(nil), fmt.Sprint(results[1].Err))
+
+	stats := parser.Stats()
+	equals("Parsed", t, 1, stats.Parsed)
+	equals("Failed", t, 1, stats.Failed)
+	equals("Errors", t, fmt.Sprint(map[string]int{
+		"data following URI found.": 1,
+	}), fmt.Sprint(stats.Errors))
+}
+
+func TestBatchParserInFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var read int64
+	inputs := make(chan []byte)
+	go func() {
+		// The first input takes long, so the following results wait for it.
+		data := []byte("http://example.com/" + strings.Repeat("a", 1<<24))
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case inputs <- data:
+				atomic.AddInt64(&read, 1)
+			}
+			data = []byte("http://example.com/")
+		}
+	}()
+	parser := &BatchParser{Workers: 2, Ordered: true}
+	results := parser.ParseAll(ctx, inputs)
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt64(&read); n > 2*inFlightPerWorker+1 {
+		t.Errorf("%v inputs are read while no result is received", n)
+	}
+	cancel()
+	for range results {
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	data := []string{}
+	for i := 0; i < 100; i++ {
+		data = append(data, fmt.Sprintf("http://example.com/%v", i))
+	}
+	received := make([]bool, len(data))
+	for result := range ParseAll(context.Background(), batchInputs(data), 4) {
+		if result.Err != nil {
+			t.Errorf("%s: %v", result.Data, result.Err)
+			continue
+		}
+		equals("Uri", t, data[result.Index], result.Uri.String())
+		received[result.Index] = true
+	}
+	for i, r := range received {
+		if !r {
+			t.Errorf("result of %v not received", i)
+		}
+	}
+}
+
+func TestParseAllCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs := make(chan []byte)
+	go func() {
+		// never closed.
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case inputs <- []byte("http://example.com/"):
+			}
+		}
+	}()
+	results := ParseAll(ctx, inputs, 4)
+	<-results
+	cancel()
+	for range results {
+	}
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	b.ReportAllocs()
+	inputs := make(chan []byte)
+	go func() {
+		defer close(inputs)
+		for i := 0; i < b.N; i++ {
+			inputs <- benchmarkUri
+		}
+	}()
+	for range ParseAll(context.Background(), inputs, 0) {
+	}
+}
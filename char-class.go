@@ -0,0 +1,194 @@
+package urip
+
+import (
+	abnfp "github.com/um7a/abnf-parser"
+)
+
+// The character sets of RFC3986 are precomputed into charClasses, so that
+// checking whether a byte belongs to a set costs one table lookup instead of
+// walking a tree of abnfp.AlternativesFinder.
+
+const (
+	classAlpha       uint16 = 1 << iota // ALPHA
+	classDigit                          // DIGIT
+	classHexDig                         // HEXDIG
+	classUnreserved                     // unreserved
+	classSubDelims                      // sub-delims
+	classGenDelims                      // gen-delims
+	classScheme                         // ALPHA / DIGIT / "+" / "-" / "."
+	classUserInfo                       // unreserved / sub-delims / ":"
+	classRegName                        // unreserved / sub-delims
+	classSegmentNzNc                    // unreserved / sub-delims / "@"
+	classPchar                          // unreserved / sub-delims / ":" / "@"
+	classQuery                          // pchar / "/" / "?"
+)
+
+var charClasses = newCharClasses()
+
+func newCharClasses() (classes [256]uint16) {
+	for c := 'A'; c <= 'Z'; c++ {
+		classes[c] |= classAlpha
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		classes[c] |= classAlpha
+	}
+	for c := '0'; c <= '9'; c++ {
+		classes[c] |= classDigit | classHexDig
+	}
+	// NOTE
+	// The same as abnfp.NewHexDigFinder, which accepts only "A" to "F".
+	// See isHexDigNoCase for the lowercase digits.
+	for _, c := range []byte("ABCDEF") {
+		classes[c] |= classHexDig
+	}
+
+	// RFC3986 - 2.3. Unreserved Characters
+	//
+	//  unreserved  = ALPHA / DIGIT / "-" / "." / "_" / "~"
+	//
+	for c := 0; c < 256; c++ {
+		if classes[c]&(classAlpha|classDigit) != 0 {
+			classes[c] |= classUnreserved
+		}
+	}
+	for _, c := range []byte("-._~") {
+		classes[c] |= classUnreserved
+	}
+
+	// RFC3986 - 2.2. Reserved Characters
+	//
+	//  gen-delims  = ":" / "/" / "?" / "#" / "[" / "]" / "@"
+	//
+	//  sub-delims  = "!" / "$" / "&" / "'" / "(" / ")"
+	//              / "*" / "+" / "," / ";" / "="
+	//
+	for _, c := range []byte(":/?#[]@") {
+		classes[c] |= classGenDelims
+	}
+	for _, c := range []byte("!$&'()*+,;=") {
+		classes[c] |= classSubDelims
+	}
+
+	for c := 0; c < 256; c++ {
+		if classes[c]&(classAlpha|classDigit) != 0 {
+			classes[c] |= classScheme
+		}
+		if classes[c]&(classUnreserved|classSubDelims) != 0 {
+			classes[c] |= classUserInfo | classRegName | classSegmentNzNc | classPchar | classQuery
+		}
+	}
+	for _, c := range []byte("+-.") {
+		classes[c] |= classScheme
+	}
+	classes[':'] |= classUserInfo | classPchar | classQuery
+	classes['@'] |= classSegmentNzNc | classPchar | classQuery
+	classes['/'] |= classQuery
+	classes['?'] |= classQuery
+	return
+}
+
+func hasClass(c byte, class uint16) bool {
+	return charClasses[c]&class != 0
+}
+
+func isAlpha(c byte) bool {
+	return hasClass(c, classAlpha)
+}
+
+func isDigit(c byte) bool {
+	return hasClass(c, classDigit)
+}
+
+func isHexDig(c byte) bool {
+	return hasClass(c, classHexDig)
+}
+
+// isHexDigNoCase is HEXDIG in either case.
+func isHexDigNoCase(c byte) bool {
+	return isHexDig(c) || c >= 'a' && c <= 'f'
+}
+
+func isUnreserved(c byte) bool {
+	return hasClass(c, classUnreserved)
+}
+
+func isSubDelims(c byte) bool {
+	return hasClass(c, classSubDelims)
+}
+
+// RFC3986 - 2.1. Percent-Encoding
+//
+//  pct-encoded   = "%" HEXDIG HEXDIG
+//
+
+func isPctEncoded(data []byte, pos int) bool {
+	return pos+2 < len(data) && data[pos] == '%' && isHexDig(data[pos+1]) && isHexDig(data[pos+2])
+}
+
+// isPctEncodedNoCase is isPctEncoded which also accepts the lowercase
+// hexadecimal digits. RFC3986 - 2.1 says that they are equivalent to the
+// uppercase ones.
+func isPctEncodedNoCase(data []byte, pos int) bool {
+	return pos+2 < len(data) && data[pos] == '%' && isHexDigNoCase(data[pos+1]) && isHexDigNoCase(data[pos+2])
+}
+
+// scanClass returns the end of *( <class> / pct-encoded ) starting at pos.
+func scanClass(data []byte, pos int, class uint16) int {
+	end, _ := scanClassCount(data, pos, class, -1)
+	return end
+}
+
+// scanClassCount is scanClass which stops after max elements (or never if
+// max is negative) and also returns the number of elements it found.
+// A pct-encoded counts as one element.
+func scanClassCount(data []byte, pos int, class uint16, max int) (end int, count int) {
+	for pos < len(data) && count != max {
+		if charClasses[data[pos]]&class != 0 {
+			pos++
+		} else if isPctEncoded(data, pos) {
+			pos += 3
+		} else {
+			break
+		}
+		count++
+	}
+	return pos, count
+}
+
+// classRunFinder finds min*( <class> / pct-encoded ).
+// It is equivalent to the abnfp.NewVariableRepetitionMinFinder tree of the
+// same rule, including Recalculate, but scans the data with a table lookup
+// per byte.
+type classRunFinder struct {
+	class uint16
+	min   int
+	count int
+}
+
+func (finder *classRunFinder) Find(data []byte) (found bool, end int) {
+	end, finder.count = scanClassCount(data, 0, finder.class, -1)
+	if finder.count < finder.min {
+		finder.count = 0
+		return false, 0
+	}
+	return true, end
+}
+
+func (finder *classRunFinder) Copy() abnfp.Finder {
+	return &classRunFinder{class: finder.class, min: finder.min, count: finder.count}
+}
+
+// Recalculate gives up the last element found, as the
+// abnfp.VariableRepetitionMinMaxFinder does.
+func (finder *classRunFinder) Recalculate(data []byte) (found bool, end int) {
+	if finder.count <= finder.min {
+		return false, 0
+	}
+	finder.count--
+	end, _ = scanClassCount(data, 0, finder.class, finder.count)
+	return true, end
+}
+
+func newClassRunFinder(min int, class uint16) *classRunFinder {
+	return &classRunFinder{class: class, min: min}
+}
@@ -0,0 +1,124 @@
+package urip
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	abnfp "github.com/um7a/abnf-parser"
+)
+
+func TestCharClasses(t *testing.T) {
+	type TestCase struct {
+		testName string
+		class    uint16
+		finder   abnfp.Finder
+	}
+	tests := []TestCase{
+		{testName: "ALPHA", class: classAlpha, finder: abnfp.NewAlphaFinder()},
+		{testName: "DIGIT", class: classDigit, finder: abnfp.NewDigitFinder()},
+		{testName: "HEXDIG", class: classHexDig, finder: abnfp.NewHexDigFinder()},
+		{testName: "unreserved", class: classUnreserved, finder: NewUnreservedFinder()},
+		{testName: "sub-delims", class: classSubDelims, finder: NewSubDelimsFinder()},
+		{testName: "gen-delims", class: classGenDelims, finder: NewGenDelimsFinder()},
+		{testName: "pchar", class: classPchar, finder: NewPcharFinder()},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			for c := 0; c < 256; c++ {
+				found, _ := testCase.finder.Copy().Find([]byte{byte(c)})
+				equals(fmt.Sprintf("%s(%#x)", testCase.testName, c), t, found, hasClass(byte(c), testCase.class))
+			}
+		})
+	}
+}
+
+func TestClassRunFinder(t *testing.T) {
+	// The finders before the charClasses table was introduced.
+	pchar := NewPcharFinder()
+	query := abnfp.NewVariableRepetitionFinder(
+		abnfp.NewAlternativesFinder([]abnfp.Finder{
+			pchar,
+			abnfp.NewByteFinder('/'),
+			abnfp.NewByteFinder('?'),
+		}),
+	)
+	segmentNz := abnfp.NewVariableRepetitionMinFinder(1, pchar)
+
+	data := []string{
+		"",
+		"a",
+		"%1A",
+		"%e3",
+		"%1",
+		"%zz",
+		"key1=value1&key2=value2",
+		"a/b?c#d",
+		"@:!$&'()*+,;=-._~",
+		"a b",
+	}
+	for _, d := range data {
+		testName := fmt.Sprintf("data: []byte(%q)", d)
+		t.Run(testName, func(t *testing.T) {
+			expectedFound, expectedEnd := query.Copy().Find([]byte(d))
+			found, end := NewQueryFinder().Find([]byte(d))
+			equals(testName+"(query)", t, expectedFound, found)
+			equals(testName+"(query)", t, expectedEnd, end)
+
+			expectedFound, expectedEnd = segmentNz.Copy().Find([]byte(d))
+			found, end = NewSegmentNzFinder().Find([]byte(d))
+			equals(testName+"(segment-nz)", t, expectedFound, found)
+			equals(testName+"(segment-nz)", t, expectedEnd, end)
+		})
+	}
+}
+
+func TestClassRunFinderRecalculate(t *testing.T) {
+	// The segment has to give up "%41" and "b" so that "b%41c" is found.
+	tests := []TestCase{
+		{
+			testName: "data: []byte(\"ab%41c\")",
+			data:     []byte("ab%41c"),
+			finder: abnfp.NewConcatenationFinder([]abnfp.Finder{
+				NewSegmentNzFinder(),
+				abnfp.NewBytesFinder([]byte("b%41c")),
+			}),
+			expectedFound: true,
+			expectedEnd:   6,
+		},
+		{
+			testName: "data: []byte(\"abc\")",
+			data:     []byte("abc"),
+			finder: abnfp.NewConcatenationFinder([]abnfp.Finder{
+				NewSegmentNzFinder(),
+				abnfp.NewBytesFinder([]byte("abc")),
+			}),
+			expectedFound: false,
+			expectedEnd:   0,
+		},
+	}
+	execTest(tests, t)
+}
+
+var benchmarkQuery = []byte(strings.Repeat("key=value%20with%20spaces&", 40))
+
+func BenchmarkQueryFinderTree(b *testing.B) {
+	b.ReportAllocs()
+	finder := abnfp.NewVariableRepetitionFinder(
+		abnfp.NewAlternativesFinder([]abnfp.Finder{
+			NewPcharFinder(),
+			abnfp.NewByteFinder('/'),
+			abnfp.NewByteFinder('?'),
+		}),
+	)
+	for i := 0; i < b.N; i++ {
+		finder.Copy().Find(benchmarkQuery)
+	}
+}
+
+func BenchmarkQueryFinder(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewQueryFinder().Find(benchmarkQuery)
+	}
+}
@@ -0,0 +1,335 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errConnectionSchemeMismatch    = errors.New("scheme of connection URI mismatch.")
+	errConnectionAuthorityNotFound = errors.New("authority of connection URI not found.")
+	errConnectionFragment          = errors.New("connection URI cannot have fragment.")
+	errConnectionHostNotFound      = errors.New("host of connection URI not found.")
+	errConnectionPathNotFound      = errors.New("path of connection URI not found.")
+	errConnectionParamNotFound     = errors.New("parameter of connection URI not found.")
+	errConnectionMultipleHosts     = errors.New("connection URI cannot have multiple hosts.")
+	errRedisDbNotFound             = errors.New("db number of redis URI not found.")
+	errMongodbSrvPort              = errors.New("mongodb+srv URI cannot have port.")
+)
+
+type ConnectionParam struct {
+	Name  string
+	Value string
+}
+
+// ConnectionParams are the decoded parameters in query, in order.
+type ConnectionParams []ConnectionParam
+
+// Get returns the value of the first parameter of the name, and whether it
+// is found.
+func (params ConnectionParams) Get(name string) (string, bool) {
+	for _, param := range params {
+		if param.Name == name {
+			return param.Value, true
+		}
+	}
+	return "", false
+}
+
+// PostgresUri is a postgres or postgresql URI of libpq.
+type PostgresUri struct {
+	User     string
+	Password string
+	Hosts    []HostPort // empty if the host is omitted, such as a Unix socket
+	Database string
+	Params   ConnectionParams
+}
+
+// ParsePostgresUri parses a postgres or postgresql URI of libpq.
+//
+//	postgresql://[userspec@][hostspec][/dbname][?paramspec]
+//
+// hostspec may be a list of host[:port] separated with ",".
+func ParsePostgresUri(data []byte) (*PostgresUri, error) {
+	conn, err := parseConnectionUri(data, "postgres", "postgresql")
+	if err != nil {
+		return nil, err
+	}
+	database, err := conn.singleSegment()
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresUri{
+		User:     conn.user,
+		Password: conn.password,
+		Hosts:    conn.hosts,
+		Database: database,
+		Params:   conn.params,
+	}, nil
+}
+
+// MysqlUri is a mysql URI.
+type MysqlUri struct {
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Database string
+	Params   ConnectionParams
+}
+
+// ParseMysqlUri parses a mysql URI.
+//
+//	mysql://[user[:password]@]host[:port][/database][?params]
+func ParseMysqlUri(data []byte) (*MysqlUri, error) {
+	conn, err := parseConnectionUri(data, "mysql")
+	if err != nil {
+		return nil, err
+	}
+	host, err := conn.singleHost()
+	if err != nil {
+		return nil, err
+	}
+	database, err := conn.singleSegment()
+	if err != nil {
+		return nil, err
+	}
+	return &MysqlUri{
+		User:     conn.user,
+		Password: conn.password,
+		Host:     host.Host,
+		Port:     host.Port,
+		Database: database,
+		Params:   conn.params,
+	}, nil
+}
+
+// RedisUri is a redis or rediss URI.
+type RedisUri struct {
+	Secure   bool // true if the scheme is rediss
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Db       int // 0 if the db number is omitted
+	Params   ConnectionParams
+}
+
+// ParseRedisUri parses a redis or rediss URI.
+//
+//	redis://[[user][:password]@]host[:port][/db-number][?params]
+func ParseRedisUri(data []byte) (*RedisUri, error) {
+	conn, err := parseConnectionUri(data, "redis", "rediss")
+	if err != nil {
+		return nil, err
+	}
+	host, err := conn.singleHost()
+	if err != nil {
+		return nil, err
+	}
+	redis := &RedisUri{
+		Secure:   conn.scheme == "rediss",
+		User:     conn.user,
+		Password: conn.password,
+		Host:     host.Host,
+		Port:     host.Port,
+		Params:   conn.params,
+	}
+	db, err := conn.singleSegment()
+	if err != nil {
+		return nil, err
+	}
+	if db != "" {
+		if !isNumber(db) {
+			return nil, errRedisDbNotFound
+		}
+		redis.Db, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, errRedisDbNotFound
+		}
+	}
+	return redis, nil
+}
+
+// AmqpUri is an amqp or amqps URI.
+type AmqpUri struct {
+	Secure   bool // true if the scheme is amqps
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Vhost    string
+	Params   ConnectionParams
+}
+
+// ParseAmqpUri parses an amqp or amqps URI.
+//
+//	amqp://[user[:password]@]host[:port][/vhost][?params]
+//
+// As the AMQP URI specification says, the vhost is "/" if the path is empty,
+// and "" if the path is "/". A "/" in vhost must be pct-encoded, such as
+// "amqp://host/%2F".
+func ParseAmqpUri(data []byte) (*AmqpUri, error) {
+	conn, err := parseConnectionUri(data, "amqp", "amqps")
+	if err != nil {
+		return nil, err
+	}
+	host, err := conn.singleHost()
+	if err != nil {
+		return nil, err
+	}
+	vhost, err := conn.singleSegment()
+	if err != nil {
+		return nil, err
+	}
+	if conn.path == "" {
+		vhost = "/"
+	}
+	return &AmqpUri{
+		Secure:   conn.scheme == "amqps",
+		User:     conn.user,
+		Password: conn.password,
+		Host:     host.Host,
+		Port:     host.Port,
+		Vhost:    vhost,
+		Params:   conn.params,
+	}, nil
+}
+
+// MongodbUri is a mongodb or mongodb+srv URI.
+type MongodbUri struct {
+	Srv          bool // true if the scheme is mongodb+srv
+	User         string
+	Password     string
+	Hosts        []HostPort
+	AuthDatabase string // defaultauthdb
+	Params       ConnectionParams
+}
+
+// ParseMongodbUri parses a mongodb or mongodb+srv URI.
+//
+//	mongodb://[user:password@]host1[:port1][,...hostN[:portN]][/[defaultauthdb][?options]]
+//
+// mongodb+srv URI has exactly one host without port.
+func ParseMongodbUri(data []byte) (*MongodbUri, error) {
+	conn, err := parseConnectionUri(data, "mongodb", "mongodb+srv")
+	if err != nil {
+		return nil, err
+	}
+	mongodb := &MongodbUri{
+		Srv:      conn.scheme == "mongodb+srv",
+		User:     conn.user,
+		Password: conn.password,
+		Hosts:    conn.hosts,
+		Params:   conn.params,
+	}
+	if len(mongodb.Hosts) == 0 {
+		return nil, errConnectionHostNotFound
+	}
+	if mongodb.Srv {
+		if len(mongodb.Hosts) > 1 {
+			return nil, errConnectionMultipleHosts
+		}
+		if mongodb.Hosts[0].Port != "" {
+			return nil, errMongodbSrvPort
+		}
+	}
+	mongodb.AuthDatabase, err = conn.singleSegment()
+	if err != nil {
+		return nil, err
+	}
+	return mongodb, nil
+}
+
+// connectionUri is the common part of the connection URIs.
+type connectionUri struct {
+	scheme   string // in lowercase
+	user     string
+	password string
+	hosts    []HostPort
+	path     string // not decoded
+	params   ConnectionParams
+}
+
+// parseConnectionUri parses a connection URI of one of the schemes.
+//
+//	scheme "://" [ userinfo "@" ] [ host [ ":" port ] *( "," host [ ":" port ] ) ]
+//	  path-abempty [ "?" query ]
+//
+// The userinfo, the reg-names and the parameters are decoded.
+func parseConnectionUri(data []byte, schemes ...string) (*connectionUri, error) {
+	uri, err := ParseMultiHost(data)
+	if err != nil {
+		return nil, err
+	}
+	conn := &connectionUri{scheme: strings.ToLower(string(uri.Scheme))}
+	matched := false
+	for _, s := range schemes {
+		matched = matched || conn.scheme == s
+	}
+	if !matched {
+		return nil, errConnectionSchemeMismatch
+	}
+	if len(uri.DoubleSlash) == 0 {
+		return nil, errConnectionAuthorityNotFound
+	}
+	if len(uri.Sharp) > 0 {
+		return nil, errConnectionFragment
+	}
+
+	if len(uri.AtSign) > 0 {
+		user, password, _ := bytes.Cut(uri.UserInfo, []byte(":"))
+		decodedUser, _ := decodePctEncoded(user)
+		decodedPassword, _ := decodePctEncoded(password)
+		conn.user = string(decodedUser)
+		conn.password = string(decodedPassword)
+	}
+	conn.hosts = uri.Hosts
+	for i, host := range conn.hosts {
+		if !strings.HasPrefix(host.Host, "[") {
+			decoded, _ := decodePctEncoded([]byte(host.Host))
+			conn.hosts[i].Host = string(decoded)
+		}
+	}
+
+	conn.path = string(uri.Path)
+	if len(uri.Query) > 0 {
+		for _, param := range strings.Split(string(uri.Query), "&") {
+			name, value, _ := strings.Cut(param, "=")
+			if name == "" {
+				return nil, errConnectionParamNotFound
+			}
+			decodedName, _ := decodePctEncoded([]byte(name))
+			decodedValue, _ := decodePctEncoded([]byte(value))
+			conn.params = append(conn.params, ConnectionParam{Name: string(decodedName), Value: string(decodedValue)})
+		}
+	}
+	return conn, nil
+}
+
+// singleHost returns the host. The host is required.
+func (conn *connectionUri) singleHost() (HostPort, error) {
+	switch len(conn.hosts) {
+	case 0:
+		return HostPort{}, errConnectionHostNotFound
+	case 1:
+		return conn.hosts[0], nil
+	}
+	return HostPort{}, errConnectionMultipleHosts
+}
+
+// singleSegment returns the decoded path without the leading "/". The path
+// must not have more than one segment.
+func (conn *connectionUri) singleSegment() (string, error) {
+	if conn.path == "" {
+		return "", nil
+	}
+	segment := conn.path[1:]
+	if strings.IndexByte(segment, '/') >= 0 {
+		return "", errConnectionPathNotFound
+	}
+	decoded, _ := decodePctEncoded([]byte(segment))
+	return string(decoded), nil
+}
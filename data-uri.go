@@ -0,0 +1,186 @@
+package urip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	errDataSchemeNotFound = errors.New("data scheme not found.")
+	errDataAuthority      = errors.New("data URI cannot have authority.")
+	errDataCommaNotFound  = errors.New("comma preceding data not found.")
+	errMediaTypeNotFound  = errors.New("mediatype not found.")
+	errParameterNotFound  = errors.New("parameter not found.")
+)
+
+// DataUri is a data URI.
+type DataUri struct {
+	MediaType string // "type/subtype" in lowercase
+	Params    []DataUriParam
+	Base64    bool
+	data      []byte // pct-decoded, and still base64 encoded if Base64
+}
+
+type DataUriParam struct {
+	Attribute string // in lowercase
+	Value     string
+}
+
+// ParseDataUri parses a data URI. The data is decoded when it is read
+// from Payload.
+//
+// RFC2397 - 3. Syntax
+//
+//	dataurl    := "data:" [ mediatype ] [ ";base64" ] "," data
+//	mediatype  := [ type "/" subtype ] *( ";" parameter )
+//	data       := *urlchar
+//	parameter  := attribute "=" value
+//
+// If <mediatype> is omitted, it defaults to text/plain;charset=US-ASCII.
+//
+// NOTE
+// Parse takes "?" in data as the start of query. Since RFC2397 allows "?" in
+// data, the query is taken as a part of data.
+func ParseDataUri(data []byte) (*DataUri, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("data")) {
+		return nil, errDataSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 {
+		return nil, errDataAuthority
+	}
+	body := append(append(append([]byte{}, uri.Path...), uri.Question...), uri.Query...)
+
+	header, encoded, found := bytes.Cut(body, []byte(","))
+	if !found {
+		return nil, errDataCommaNotFound
+	}
+	dataUri := &DataUri{}
+	dataUri.data, err = decodePctEncoded(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	// ";" in a value is pct-encoded. So split the header before decoding.
+	parts := []string{}
+	for _, part := range bytes.Split(header, []byte(";")) {
+		decoded, err := decodePctEncoded(part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, string(decoded))
+	}
+	if last := parts[len(parts)-1]; len(parts) > 1 && strings.EqualFold(last, "base64") {
+		dataUri.Base64 = true
+		parts = parts[:len(parts)-1]
+	}
+
+	// [ type "/" subtype ]
+	if parts[0] == "" {
+		dataUri.MediaType = "text/plain"
+	} else {
+		mediaType, subType, found := strings.Cut(parts[0], "/")
+		if !found || !isToken(mediaType) || !isToken(subType) {
+			return nil, errMediaTypeNotFound
+		}
+		dataUri.MediaType = strings.ToLower(parts[0])
+	}
+
+	// *( ";" parameter )
+	for _, parameter := range parts[1:] {
+		attribute, value, found := strings.Cut(parameter, "=")
+		if !found || !isToken(attribute) {
+			return nil, errParameterNotFound
+		}
+		dataUri.Params = append(dataUri.Params, DataUriParam{
+			Attribute: strings.ToLower(attribute),
+			Value:     value,
+		})
+	}
+	if parts[0] == "" && dataUri.Param("charset") == "" {
+		dataUri.Params = append(dataUri.Params, DataUriParam{Attribute: "charset", Value: "US-ASCII"})
+	}
+	return dataUri, nil
+}
+
+// NewDataUri makes a data URI of payload. If base64Encoded is true, payload is
+// encoded with base64, otherwise it is pct-encoded.
+func NewDataUri(mediaType string, params []DataUriParam, payload []byte, base64Encoded bool) *DataUri {
+	dataUri := &DataUri{
+		MediaType: strings.ToLower(mediaType),
+		Params:    params,
+		Base64:    base64Encoded,
+		data:      payload,
+	}
+	if base64Encoded {
+		dataUri.data = []byte(base64.StdEncoding.EncodeToString(payload))
+	}
+	return dataUri
+}
+
+// Param returns the value of the parameter, or "" if it is not found.
+func (dataUri *DataUri) Param(attribute string) string {
+	for _, param := range dataUri.Params {
+		if strings.EqualFold(param.Attribute, attribute) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+func (dataUri *DataUri) Charset() string {
+	return dataUri.Param("charset")
+}
+
+// Payload returns the reader of the decoded data.
+func (dataUri *DataUri) Payload() io.Reader {
+	reader := io.Reader(bytes.NewReader(dataUri.data))
+	if dataUri.Base64 {
+		reader = base64.NewDecoder(base64.StdEncoding, reader)
+	}
+	return reader
+}
+
+func (dataUri *DataUri) String() string {
+	str := "data:"
+	str += dataUri.MediaType
+	for _, param := range dataUri.Params {
+		str += ";" + param.Attribute + "=" + string(encodePctEncoded([]byte(param.Value), isUnreserved))
+	}
+	if dataUri.Base64 {
+		str += ";base64"
+	}
+	str += ","
+	str += string(encodePctEncoded(dataUri.data, func(c byte) bool {
+		return c == '/' || hasClass(c, classPchar)
+	}))
+	return str
+}
+
+// RFC2045 - 5.1. Syntax of the Content-Type Header Field
+//
+//  token := 1*<any (US-ASCII) CHAR except SPACE, CTLs,
+//              or tspecials>
+//  tspecials :=  "(" / ")" / "<" / ">" / "@" /
+//                "," / ";" / ":" / "\" / <">
+//                "/" / "[" / "]" / "?" / "="
+//
+
+func isToken(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte("()<>@,;:\\\"/[]?=", c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
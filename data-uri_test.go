@@ -0,0 +1,158 @@
+package urip
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestParseDataUri(t *testing.T) {
+	type TestCase struct {
+		testName          string
+		data              []byte
+		expectedMediaType string
+		expectedParams    []DataUriParam
+		expectedBase64    bool
+		expectedPayload   []byte
+		expectedErr       error
+	}
+
+	tests := []TestCase{
+		// RFC2397 - 4. Examples
+		{
+			testName:          "data: []byte(\"data:,A%20brief%20note\")",
+			data:              []byte("data:,A%20brief%20note"),
+			expectedMediaType: "text/plain",
+			expectedParams:    []DataUriParam{{Attribute: "charset", Value: "US-ASCII"}},
+			expectedPayload:   []byte("A brief note"),
+		},
+		{
+			testName:          "data: []byte(\"data:text/plain;charset=iso-8859-7,%be%fg%be\")",
+			data:              []byte("data:text/plain;charset=iso-8859-7,%be%d3%be"),
+			expectedMediaType: "text/plain",
+			expectedParams:    []DataUriParam{{Attribute: "charset", Value: "iso-8859-7"}},
+			expectedPayload:   []byte{0xbe, 0xd3, 0xbe},
+		},
+		{
+			testName:          "data: []byte(\"data:image/gif;base64,R0lGODdh\")",
+			data:              []byte("data:image/GIF;base64,R0lGODdh"),
+			expectedMediaType: "image/gif",
+			expectedParams:    []DataUriParam{},
+			expectedBase64:    true,
+			expectedPayload:   []byte("GIF87a"),
+		},
+		{
+			testName:          "data: []byte(\"data:;charset=utf-8;base64,44GC\")",
+			data:              []byte("data:;charset=utf-8;base64,44GC"),
+			expectedMediaType: "text/plain",
+			expectedParams:    []DataUriParam{{Attribute: "charset", Value: "utf-8"}},
+			expectedBase64:    true,
+			expectedPayload:   []byte("あ"),
+		},
+		{
+			testName:          "data: []byte(\"data:text/plain,a,b?c\")",
+			data:              []byte("data:text/plain,a,b?c"),
+			expectedMediaType: "text/plain",
+			expectedParams:    []DataUriParam{},
+			expectedPayload:   []byte("a,b?c"),
+		},
+		{
+			testName:          "data: []byte(\"data:text/plain;name=a%3Bb,\")",
+			data:              []byte("data:text/plain;name=a%3Bb,"),
+			expectedMediaType: "text/plain",
+			expectedParams:    []DataUriParam{{Attribute: "name", Value: "a;b"}},
+			expectedPayload:   []byte{},
+		},
+		{
+			testName:    "data: []byte(\"http:,a\")",
+			data:        []byte("http:,a"),
+			expectedErr: errDataSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"data://example.com/,a\")",
+			data:        []byte("data://example.com/,a"),
+			expectedErr: errDataAuthority,
+		},
+		{
+			testName:    "data: []byte(\"data:text/plain\")",
+			data:        []byte("data:text/plain"),
+			expectedErr: errDataCommaNotFound,
+		},
+		{
+			testName:    "data: []byte(\"data:text,a\")",
+			data:        []byte("data:text,a"),
+			expectedErr: errMediaTypeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"data:text/plain;charset,a\")",
+			data:        []byte("data:text/plain;charset,a"),
+			expectedErr: errParameterNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			dataUri, err := ParseDataUri(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(MediaType)", t, testCase.expectedMediaType, dataUri.MediaType)
+			sliceHasSameElem(testCase.testName+"(Params)", t, testCase.expectedParams, dataUri.Params)
+			equals(testCase.testName+"(Base64)", t, testCase.expectedBase64, dataUri.Base64)
+			payload, err := io.ReadAll(dataUri.Payload())
+			if err != nil {
+				t.Errorf("%s: Failed to read payload: %v", testCase.testName, err.Error())
+				return
+			}
+			byteEquals(testCase.testName+"(Payload)", t, testCase.expectedPayload, payload)
+		})
+	}
+}
+
+func TestDataUriPayloadError(t *testing.T) {
+	dataUri, err := ParseDataUri([]byte("data:image/gif;base64,R0l!"))
+	if err != nil {
+		t.Errorf("Failed to parse data URI: %v", err.Error())
+		return
+	}
+	if _, err := io.ReadAll(dataUri.Payload()); err == nil {
+		t.Errorf("error expected for invalid base64")
+	}
+}
+
+func TestNewDataUri(t *testing.T) {
+	type TestCase struct {
+		testName string
+		dataUri  *DataUri
+		expected string
+	}
+
+	tests := []TestCase{
+		{
+			testName: "base64",
+			dataUri:  NewDataUri("image/png", nil, []byte{0x89, 'P', 'N', 'G'}, true),
+			expected: "data:image/png;base64,iVBORw==",
+		},
+		{
+			testName: "pct-encoded",
+			dataUri:  NewDataUri("text/plain", []DataUriParam{{Attribute: "charset", Value: "utf-8"}}, []byte("a b?#あ"), false),
+			expected: "data:text/plain;charset=utf-8,a%20b%3F%23%E3%81%82",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			equals(testCase.testName, t, testCase.expected, testCase.dataUri.String())
+
+			parsed, err := ParseDataUri([]byte(testCase.dataUri.String()))
+			if err != nil {
+				t.Errorf("Failed to parse data URI: %v", err.Error())
+				return
+			}
+			expectedPayload, _ := io.ReadAll(testCase.dataUri.Payload())
+			payload, _ := io.ReadAll(parsed.Payload())
+			byteEquals(testCase.testName+"(Payload)", t, expectedPayload, payload)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package urip
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	errDidSchemeNotFound = errors.New("did scheme not found.")
+	errDidMethodNotFound = errors.New("method-name of DID not found.")
+	errDidIdNotFound     = errors.New("method-specific-id of DID not found.")
+	errDidUrl            = errors.New("DID cannot have path, query or fragment.")
+)
+
+// Did is a decentralized identifier.
+type Did struct {
+	Method string
+	Id     string // method-specific-id, not decoded
+}
+
+// DidUrl is a DID URL. Path, Query and Fragment are not decoded, and are ""
+// if they are omitted.
+type DidUrl struct {
+	Did
+	Path     string // path-abempty
+	Query    string
+	Fragment string
+}
+
+// ParseDid parses a DID. A DID URL with path, query or fragment is not
+// accepted.
+//
+// W3C Decentralized Identifiers (DIDs) v1.0 - 3.1 DID Syntax
+//
+//	did                = "did:" method-name ":" method-specific-id
+//	method-name        = 1*method-char
+//	method-char        = %x61-7A / DIGIT
+//	method-specific-id = *( *idchar ":" ) 1*idchar
+//	idchar             = ALPHA / DIGIT / "." / "-" / "_" / pct-encoded
+//	pct-encoded        = "%" HEXDIG HEXDIG
+func ParseDid(data []byte) (*Did, error) {
+	didUrl, err := ParseDidUrl(data)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(string(data), "/?#") {
+		return nil, errDidUrl
+	}
+	return &didUrl.Did, nil
+}
+
+// ParseDidUrl parses a DID URL.
+//
+// W3C Decentralized Identifiers (DIDs) v1.0 - 3.2 DID URL Syntax
+//
+//	did-url = did path-abempty [ "?" query ] [ "#" fragment ]
+//
+// NOTE
+// The scheme "did" is case sensitive, since the DID scheme name MUST be an
+// ASCII lowercase string.
+func ParseDidUrl(data []byte) (*DidUrl, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if string(uri.Scheme) != "did" || len(uri.DoubleSlash) > 0 {
+		return nil, errDidSchemeNotFound
+	}
+	didUrl := &DidUrl{
+		Query:    string(uri.Query),
+		Fragment: string(uri.Fragment),
+	}
+
+	path := string(uri.Path)
+	if slash := strings.IndexByte(path, '/'); slash >= 0 {
+		path, didUrl.Path = path[:slash], path[slash:]
+	}
+	method, id, found := strings.Cut(path, ":")
+	if !found || !isDidMethodName(method) {
+		return nil, errDidMethodNotFound
+	}
+	if !isDidMethodSpecificId(id) {
+		return nil, errDidIdNotFound
+	}
+	didUrl.Method = method
+	didUrl.Id = id
+	return didUrl, nil
+}
+
+// Param returns the decoded value of the first parameter of the name in
+// query, and whether it is found. The parameters of DID Core are "service",
+// "relativeRef", "versionId", "versionTime" and "hl".
+func (didUrl *DidUrl) Param(name string) (string, bool) {
+	if didUrl.Query == "" {
+		return "", false
+	}
+	for _, param := range strings.Split(didUrl.Query, "&") {
+		encodedName, encodedValue, _ := strings.Cut(param, "=")
+		decodedName, err := decodePctEncoded([]byte(encodedName))
+		if err != nil || string(decodedName) != name {
+			continue
+		}
+		decodedValue, err := decodePctEncoded([]byte(encodedValue))
+		if err != nil {
+			continue
+		}
+		return string(decodedValue), true
+	}
+	return "", false
+}
+
+func (did *Did) String() string {
+	return "did:" + did.Method + ":" + did.Id
+}
+
+func (didUrl *DidUrl) String() string {
+	str := didUrl.Did.String()
+	str += didUrl.Path
+	if didUrl.Query != "" {
+		str += "?" + didUrl.Query
+	}
+	if didUrl.Fragment != "" {
+		str += "#" + didUrl.Fragment
+	}
+	return str
+}
+
+func isDidMethodName(str string) bool {
+	if str == "" {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if !('a' <= str[i] && str[i] <= 'z') && !isDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDidMethodSpecificId(str string) bool {
+	segments := strings.Split(str, ":")
+	if segments[len(segments)-1] == "" {
+		return false
+	}
+	for _, segment := range segments {
+		for i := 0; i < len(segment); i++ {
+			c := segment[i]
+			if c == '%' && isPctEncodedNoCase([]byte(segment), i) {
+				i += 2
+				continue
+			}
+			if !isAlpha(c) && !isDigit(c) && c != '.' && c != '-' && c != '_' {
+				return false
+			}
+		}
+	}
+	return true
+}
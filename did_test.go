@@ -0,0 +1,104 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseDid(t *testing.T) {
+	type TestCase struct {
+		data        string
+		expected    Did
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{data: "did:example:123456789abcdefghi", expected: Did{Method: "example", Id: "123456789abcdefghi"}},
+		{data: "did:web:w3c-ccg.github.io:user:alice", expected: Did{Method: "web", Id: "w3c-ccg.github.io:user:alice"}},
+		{data: "did:web:example.com%3A3000", expected: Did{Method: "web", Id: "example.com%3A3000"}},
+		{data: "did:ion::a", expected: Did{Method: "ion", Id: ":a"}},
+		{data: "DID:example:123", expectedErr: errDidSchemeNotFound},
+		{data: "did://example:123", expectedErr: errDidSchemeNotFound},
+		{data: "did:Example:123", expectedErr: errDidMethodNotFound},
+		{data: "did:example", expectedErr: errDidMethodNotFound},
+		{data: "did::123", expectedErr: errDidMethodNotFound},
+		{data: "did:example:", expectedErr: errDidIdNotFound},
+		{data: "did:example:123:", expectedErr: errDidIdNotFound},
+		{data: "did:example:a~b", expectedErr: errDidIdNotFound},
+		{data: "did:example:a%2", expectedErr: errTrailingData},
+		{data: "did:example:123/path", expectedErr: errDidUrl},
+		{data: "did:example:123?", expectedErr: errDidUrl},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			did, err := ParseDid([]byte(testCase.data))
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.data, t, fmt.Sprintf("%+v", testCase.expected), fmt.Sprintf("%+v", *did))
+			equals(testCase.data+"(String)", t, testCase.data, did.String())
+		})
+	}
+}
+
+func TestParseDidUrl(t *testing.T) {
+	type TestCase struct {
+		data        string
+		expected    DidUrl
+		expectedErr error
+	}
+
+	// W3C Decentralized Identifiers (DIDs) v1.0 - 3.2 DID URL Syntax
+	tests := []TestCase{
+		{
+			data:     "did:example:123/path/to/rsrc",
+			expected: DidUrl{Did: Did{Method: "example", Id: "123"}, Path: "/path/to/rsrc"},
+		},
+		{
+			data:     "did:example:123?service=agent&relativeRef=/credentials#degree",
+			expected: DidUrl{Did: Did{Method: "example", Id: "123"}, Query: "service=agent&relativeRef=/credentials", Fragment: "degree"},
+		},
+		{
+			data:     "did:example:123?versionTime=2021-05-10T17:00:00Z",
+			expected: DidUrl{Did: Did{Method: "example", Id: "123"}, Query: "versionTime=2021-05-10T17:00:00Z"},
+		},
+		{
+			data:     "did:example:123#public-key-0",
+			expected: DidUrl{Did: Did{Method: "example", Id: "123"}, Fragment: "public-key-0"},
+		},
+		{data: "did:example:123/a b", expectedErr: errTrailingData},
+		{data: "did:example:1@2/path", expectedErr: errDidIdNotFound},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			didUrl, err := ParseDidUrl([]byte(testCase.data))
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.data, t, fmt.Sprintf("%+v", testCase.expected), fmt.Sprintf("%+v", *didUrl))
+			equals(testCase.data+"(String)", t, testCase.data, didUrl.String())
+		})
+	}
+}
+
+func TestDidUrlParam(t *testing.T) {
+	didUrl, err := ParseDidUrl([]byte("did:example:123?service=files&relativeRef=%2Fresume.pdf&versionId=1&hl=zQm"))
+	if err != nil {
+		t.Errorf("Failed to parse: %v", err.Error())
+		return
+	}
+	for name, expected := range map[string]string{
+		"service":     "files true",
+		"relativeRef": "/resume.pdf true",
+		"versionId":   "1 true",
+		"hl":          "zQm true",
+		"versionTime": " false",
+	} {
+		value, found := didUrl.Param(name)
+		equals(name, t, expected, fmt.Sprintf("%v %v", value, found))
+	}
+}
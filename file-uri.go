@@ -0,0 +1,217 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	errFileSchemeNotFound    = errors.New("file scheme not found.")
+	errFileUserInfo          = errors.New("file URI cannot have userinfo.")
+	errFilePort              = errors.New("file URI cannot have port.")
+	errFileQuery             = errors.New("file URI cannot have query or fragment.")
+	errFilePathNotFound      = errors.New("path-absolute of file URI not found.")
+	errFileInvalidOctet      = errors.New("\"/\" or NUL in file name.")
+	errFileHostForPosix      = errors.New("file URI with host cannot be POSIX path.")
+	errFileBackslash         = errors.New("\"\\\" in file name cannot be Windows path.")
+	errFileRelativePath      = errors.New("relative path cannot be file URI.")
+	errFileUnknownPathStyle  = errors.New("unknown path style.")
+	errFileUncShareNotFound  = errors.New("share of UNC path not found.")
+	errFileDriveWithoutSlash = errors.New("drive letter without \"/\" cannot be file URI.")
+)
+
+// FileUri is a file URI.
+type FileUri struct {
+	// Host is the host of a non-local file, such as the server of a UNC path.
+	// It is "" for a local file, including "localhost".
+	Host string
+	// Path is the decoded path-absolute, such as "/etc/hosts" or
+	// "/C:/Windows". A DOS drive letter follows the first "/".
+	Path string
+}
+
+type PathStyle int
+
+const (
+	PosixPath PathStyle = iota
+	WindowsPath
+)
+
+// ParseFileUri parses a file URI.
+//
+// RFC8089 - 2. Syntax
+//
+//	file-URI       = file-scheme ":" file-hier-part
+//	file-hier-part = ( "//" auth-path )
+//	               / local-path
+//	auth-path      = [ file-auth ] path-absolute
+//	local-path     = path-absolute
+//	file-auth      = "localhost"
+//	               / host
+//
+// The following forms of RFC8089 - Appendix E are also accepted.
+//
+//	file:c:/path/to/file        ; E.2.  DOS and Windows Drive Letters
+//	file:////host/share/file    ; E.3.2. <file> URI with UNC Path
+func ParseFileUri(data []byte) (*FileUri, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("file")) {
+		return nil, errFileSchemeNotFound
+	}
+	if len(uri.Question) > 0 || len(uri.Sharp) > 0 {
+		return nil, errFileQuery
+	}
+	if len(uri.AtSign) > 0 {
+		return nil, errFileUserInfo
+	}
+	if len(uri.Port) > 0 {
+		return nil, errFilePort
+	}
+
+	path := uri.Path
+	fileUri := &FileUri{}
+	if len(uri.DoubleSlash) > 0 {
+		host, err := decodePctEncoded(uri.Host)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(string(host), "localhost") {
+			fileUri.Host = string(host)
+		}
+		if fileUri.Host == "" && bytes.HasPrefix(path, []byte("//")) {
+			// E.3.2. file:////host/share/file
+			uncHost, uncPath, _ := bytes.Cut(path[2:], []byte("/"))
+			host, err := decodePctEncoded(uncHost)
+			if err != nil {
+				return nil, err
+			}
+			fileUri.Host = string(host)
+			path = append([]byte("/"), uncPath...)
+		}
+	} else if isDriveLetter(path) {
+		// E.2. file:c:/path/to/file
+		path = append([]byte("/"), path...)
+	}
+	if len(path) == 0 || path[0] != '/' {
+		return nil, errFilePathNotFound
+	}
+
+	// "/" and NUL cannot be in a file name, so a pct-encoded of them is
+	// not decoded into the path.
+	segments := []string{}
+	for _, segment := range bytes.Split(path, []byte("/")) {
+		decoded, err := decodePctEncoded(segment)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.IndexByte(decoded, '/') >= 0 || bytes.IndexByte(decoded, 0) >= 0 {
+			return nil, errFileInvalidOctet
+		}
+		segments = append(segments, string(decoded))
+	}
+	fileUri.Path = strings.Join(segments, "/")
+	return fileUri, nil
+}
+
+// FileUriFromPath makes a file URI of an absolute path of the style.
+//
+//	/etc/hosts                  ; PosixPath
+//	C:\Windows\win.ini          ; WindowsPath
+//	\\server\share\file.txt     ; WindowsPath (UNC)
+//	\temp\file.txt              ; WindowsPath (the current drive)
+//
+// WindowsPath also accepts "/" as a separator.
+func FileUriFromPath(path string, style PathStyle) (*FileUri, error) {
+	switch style {
+	case PosixPath:
+		if !strings.HasPrefix(path, "/") {
+			return nil, errFileRelativePath
+		}
+		return &FileUri{Path: path}, nil
+	case WindowsPath:
+		path = strings.ReplaceAll(path, "\\", "/")
+		if strings.HasPrefix(path, "//") {
+			host, share, _ := strings.Cut(path[2:], "/")
+			if host == "" || share == "" {
+				return nil, errFileUncShareNotFound
+			}
+			return &FileUri{Host: host, Path: "/" + share}, nil
+		}
+		if isDriveLetter([]byte(path)) {
+			if len(path) == 2 {
+				// "C:" is the current directory of the drive.
+				return nil, errFileDriveWithoutSlash
+			}
+			return &FileUri{Path: "/" + path}, nil
+		}
+		if !strings.HasPrefix(path, "/") {
+			return nil, errFileRelativePath
+		}
+		return &FileUri{Path: path}, nil
+	}
+	return nil, errFileUnknownPathStyle
+}
+
+// Drive returns the DOS drive letter such as "C:", or "" if the path does
+// not have it.
+func (fileUri *FileUri) Drive() string {
+	if len(fileUri.Path) > 0 && isDriveLetter([]byte(fileUri.Path[1:])) {
+		return fileUri.Path[1:3]
+	}
+	return ""
+}
+
+// ToPath converts the file URI to a path of the style.
+func (fileUri *FileUri) ToPath(style PathStyle) (string, error) {
+	switch style {
+	case PosixPath:
+		if fileUri.Host != "" {
+			return "", errFileHostForPosix
+		}
+		return fileUri.Path, nil
+	case WindowsPath:
+		if strings.IndexByte(fileUri.Path, '\\') >= 0 {
+			return "", errFileBackslash
+		}
+		path := strings.ReplaceAll(fileUri.Path, "/", "\\")
+		if fileUri.Host != "" {
+			return "\\\\" + fileUri.Host + path, nil
+		}
+		if fileUri.Drive() != "" {
+			path = path[1:]
+			if len(path) == 2 {
+				path += "\\"
+			}
+		}
+		return path, nil
+	}
+	return "", errFileUnknownPathStyle
+}
+
+func (fileUri *FileUri) String() string {
+	str := "file://"
+	// An IP-literal, such as "[::1]", is not pct-encoded.
+	literal := len(fileUri.Host) > 1 && fileUri.Host[0] == '[' && fileUri.Host[len(fileUri.Host)-1] == ']'
+	str += string(encodePctEncoded([]byte(fileUri.Host), func(c byte) bool {
+		return hasClass(c, classRegName) || literal && (c == ':' || c == '[' || c == ']')
+	}))
+	str += string(encodePctEncoded([]byte(fileUri.Path), func(c byte) bool {
+		return c == '/' || hasClass(c, classPchar)
+	}))
+	return str
+}
+
+// RFC8089 - Appendix E.2. DOS and Windows Drive Letters
+//
+//	drive-letter   = ALPHA ":"
+//
+
+// isDriveLetter reports whether path starts with drive-letter followed by
+// "/" or nothing.
+func isDriveLetter(path []byte) bool {
+	return len(path) >= 2 && isAlpha(path[0]) && path[1] == ':' && (len(path) == 2 || path[2] == '/')
+}
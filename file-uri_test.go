@@ -0,0 +1,200 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseFileUri(t *testing.T) {
+	type TestCase struct {
+		testName     string
+		data         []byte
+		expectedHost string
+		expectedPath string
+		expectedErr  error
+	}
+
+	tests := []TestCase{
+		// RFC8089 - Appendix B. Example URIs
+		{
+			testName:     "data: []byte(\"file:///path/to/file\")",
+			data:         []byte("file:///path/to/file"),
+			expectedPath: "/path/to/file",
+		},
+		{
+			testName:     "data: []byte(\"file:/path/to/file\")",
+			data:         []byte("file:/path/to/file"),
+			expectedPath: "/path/to/file",
+		},
+		{
+			testName:     "data: []byte(\"file://host.example.com/path/to/file\")",
+			data:         []byte("file://host.example.com/path/to/file"),
+			expectedHost: "host.example.com",
+			expectedPath: "/path/to/file",
+		},
+		{
+			testName:     "data: []byte(\"file://localhost/etc/fstab\")",
+			data:         []byte("file://LocalHost/etc/fstab"),
+			expectedPath: "/etc/fstab",
+		},
+		// RFC8089 - Appendix E.2. DOS and Windows Drive Letters
+		{
+			testName:     "data: []byte(\"file:///c:/path/to/file\")",
+			data:         []byte("file:///c:/path/to/file"),
+			expectedPath: "/c:/path/to/file",
+		},
+		{
+			testName:     "data: []byte(\"file:c:/path/to/file\")",
+			data:         []byte("file:c:/path/to/file"),
+			expectedPath: "/c:/path/to/file",
+		},
+		// RFC8089 - Appendix E.3. UNC Strings
+		{
+			testName:     "data: []byte(\"file://host.example.com/share/path/to/file.txt\")",
+			data:         []byte("file://host.example.com/share/path/to/file.txt"),
+			expectedHost: "host.example.com",
+			expectedPath: "/share/path/to/file.txt",
+		},
+		{
+			testName:     "data: []byte(\"file:////host.example.com/share/path/to/file.txt\")",
+			data:         []byte("file:////host.example.com/share/path/to/file.txt"),
+			expectedHost: "host.example.com",
+			expectedPath: "/share/path/to/file.txt",
+		},
+		{
+			testName:     "data: []byte(\"file:///tmp/a%20b/%E6%97%A5\")",
+			data:         []byte("file:///tmp/a%20b/%E6%97%A5"),
+			expectedPath: "/tmp/a b/日",
+		},
+		{
+			testName:    "data: []byte(\"http://example.com/\")",
+			data:        []byte("http://example.com/"),
+			expectedErr: errFileSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"file:///tmp?q\")",
+			data:        []byte("file:///tmp?q"),
+			expectedErr: errFileQuery,
+		},
+		{
+			testName:    "data: []byte(\"file://user@host/tmp\")",
+			data:        []byte("file://user@host/tmp"),
+			expectedErr: errFileUserInfo,
+		},
+		{
+			testName:    "data: []byte(\"file://host:22/tmp\")",
+			data:        []byte("file://host:22/tmp"),
+			expectedErr: errFilePort,
+		},
+		{
+			testName:    "data: []byte(\"file:tmp\")",
+			data:        []byte("file:tmp"),
+			expectedErr: errFilePathNotFound,
+		},
+		{
+			testName:    "data: []byte(\"file:///a%2Fb\")",
+			data:        []byte("file:///a%2Fb"),
+			expectedErr: errFileInvalidOctet,
+		},
+		{
+			testName:    "data: []byte(\"file:///a%00\")",
+			data:        []byte("file:///a%00"),
+			expectedErr: errFileInvalidOctet,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			fileUri, err := ParseFileUri(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(Host)", t, testCase.expectedHost, fileUri.Host)
+			equals(testCase.testName+"(Path)", t, testCase.expectedPath, fileUri.Path)
+		})
+	}
+}
+
+func TestFileUriPath(t *testing.T) {
+	type TestCase struct {
+		path        string
+		style       PathStyle
+		expectedUri string
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{path: "/etc/hosts", style: PosixPath, expectedUri: "file:///etc/hosts"},
+		{path: "/tmp/a b/日", style: PosixPath, expectedUri: "file:///tmp/a%20b/%E6%97%A5"},
+		{path: "/tmp/a\\b", style: PosixPath, expectedUri: "file:///tmp/a%5Cb"},
+		{path: "C:\\Windows\\win.ini", style: WindowsPath, expectedUri: "file:///C:/Windows/win.ini"},
+		{path: "C:\\", style: WindowsPath, expectedUri: "file:///C:/"},
+		{path: "\\\\server\\share\\file.txt", style: WindowsPath, expectedUri: "file://server/share/file.txt"},
+		{path: "\\temp\\file.txt", style: WindowsPath, expectedUri: "file:///temp/file.txt"},
+		{path: "tmp", style: PosixPath, expectedErr: errFileRelativePath},
+		{path: "temp\\file.txt", style: WindowsPath, expectedErr: errFileRelativePath},
+		{path: "C:", style: WindowsPath, expectedErr: errFileDriveWithoutSlash},
+		{path: "\\\\server", style: WindowsPath, expectedErr: errFileUncShareNotFound},
+		{path: "/", style: PathStyle(2), expectedErr: errFileUnknownPathStyle},
+	}
+
+	for _, testCase := range tests {
+		testName := fmt.Sprintf("path: %q, style: %v", testCase.path, testCase.style)
+		t.Run(testName, func(t *testing.T) {
+			fileUri, err := FileUriFromPath(testCase.path, testCase.style)
+			equals(testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testName+"(String)", t, testCase.expectedUri, fileUri.String())
+
+			parsed, err := ParseFileUri([]byte(fileUri.String()))
+			if err != nil {
+				t.Errorf("Failed to parse file URI: %v", err.Error())
+				return
+			}
+			path, err := parsed.ToPath(testCase.style)
+			if err != nil {
+				t.Errorf("Failed to convert to path: %v", err.Error())
+				return
+			}
+			equals(testName+"(ToPath)", t, testCase.path, path)
+		})
+	}
+}
+
+func TestFileUriToPath(t *testing.T) {
+	fileUri := &FileUri{Host: "server", Path: "/share/file.txt"}
+	_, err := fileUri.ToPath(PosixPath)
+	equals("Host", t, fmt.Sprint(errFileHostForPosix), fmt.Sprint(err))
+
+	fileUri = &FileUri{Path: "/tmp/a\\b"}
+	_, err = fileUri.ToPath(WindowsPath)
+	equals("Backslash", t, fmt.Sprint(errFileBackslash), fmt.Sprint(err))
+
+	fileUri = &FileUri{Path: "/c:/path/to/file"}
+	equals("Drive", t, "c:", fileUri.Drive())
+	path, _ := fileUri.ToPath(PosixPath)
+	equals("PosixPath", t, "/c:/path/to/file", path)
+	path, _ = fileUri.ToPath(WindowsPath)
+	equals("WindowsPath", t, "c:\\path\\to\\file", path)
+}
+
+func TestFileUriString(t *testing.T) {
+	data := []string{
+		"file:///etc/hosts",
+		"file://server/share/file.txt",
+		"file://[::1]/x",
+		"file://[v1F.a]/x",
+		"file://a%3Ab/x",
+	}
+	for _, d := range data {
+		fileUri, err := ParseFileUri([]byte(d))
+		if err != nil {
+			t.Errorf("%s: %v", d, err)
+			continue
+		}
+		equals(d+"(String)", t, d, fileUri.String())
+	}
+}
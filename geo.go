@@ -0,0 +1,237 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	errGeoSchemeNotFound     = errors.New("geo scheme not found.")
+	errGeoAuthority          = errors.New("geo URI cannot have authority.")
+	errGeoQueryOrFragment    = errors.New("geo URI cannot have query or fragment.")
+	errCoordinatesNotFound   = errors.New("coordinates not found.")
+	errLatitudeOutOfRange    = errors.New("latitude out of range.")
+	errLongitudeOutOfRange   = errors.New("longitude out of range.")
+	errCrsLabelNotFound      = errors.New("crslabel not found.")
+	errUvalNotFound          = errors.New("uval not found.")
+	errGeoParameterNotFound  = errors.New("parameter not found.")
+	errGeoParameterOrder     = errors.New("crs and u parameters must precede the other parameters.")
+	errGeoParameterDuplicate = errors.New("parameter appears more than once.")
+)
+
+// Geo is a geo URI.
+type Geo struct {
+	// Crs is the crslabel in lowercase. It is "wgs84" if crs is omitted.
+	Crs string
+	// CoordA, CoordB and CoordC are the coordinates. For "wgs84", they are
+	// the latitude, the longitude and the altitude in meters.
+	CoordA float64
+	CoordB float64
+	CoordC *float64 // nil if coord-c is omitted
+	// Uncertainty is the uval in meters, nil if u is omitted.
+	Uncertainty *float64
+	// Params are the parameters other than crs and u. The names are in
+	// lowercase and the values are decoded.
+	Params []GeoParam
+}
+
+type GeoParam struct {
+	Name  string
+	Value string // "" if the parameter does not have "=" pvalue
+}
+
+// ParseGeo parses a geo URI. The coordinates of "wgs84" are validated to be
+// in range.
+//
+// RFC5870 - 3.3. URI Scheme Syntax
+//
+//	geo-URI       = geo-scheme ":" geo-path
+//	geo-path      = coordinates p
+//	coordinates   = coord-a "," coord-b [ "," coord-c ]
+//	num           = [ "-" ] pnum
+//	pnum          = 1*DIGIT [ "." 1*DIGIT ]
+//	p             = [ crsp ] [ uncp ] *parameter
+//	crsp          = ";crs=" crslabel
+//	crslabel      = "wgs84" / labeltext
+//	uncp          = ";u=" uval
+//	uval          = pnum
+//	parameter     = ";" pname [ "=" pvalue ]
+//	pname         = labeltext
+//	labeltext     = 1*( alphanum / "-" )
+func ParseGeo(data []byte) (*Geo, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("geo")) {
+		return nil, errGeoSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 {
+		return nil, errGeoAuthority
+	}
+	if len(uri.Question) > 0 || len(uri.Sharp) > 0 {
+		return nil, errGeoQueryOrFragment
+	}
+
+	parts := strings.Split(string(uri.Path), ";")
+	coords := strings.Split(parts[0], ",")
+	if len(coords) < 2 || len(coords) > 3 {
+		return nil, errCoordinatesNotFound
+	}
+	nums := []float64{}
+	for _, coord := range coords {
+		if !isGeoNum(coord) {
+			return nil, errCoordinatesNotFound
+		}
+		num, _ := strconv.ParseFloat(coord, 64)
+		nums = append(nums, num)
+	}
+	geo := &Geo{Crs: "wgs84", CoordA: nums[0], CoordB: nums[1]}
+	if len(nums) == 3 {
+		geo.CoordC = &nums[2]
+	}
+
+	found := map[string]bool{}
+	for i, parameter := range parts[1:] {
+		name, value, hasValue := strings.Cut(parameter, "=")
+		if !isPname([]byte(name)) {
+			return nil, errGeoParameterNotFound
+		}
+		name = strings.ToLower(name)
+		if found[name] {
+			return nil, errGeoParameterDuplicate
+		}
+		found[name] = true
+		switch name {
+		case "crs":
+			if i != 0 {
+				return nil, errGeoParameterOrder
+			}
+			if !isPname([]byte(value)) {
+				return nil, errCrsLabelNotFound
+			}
+			geo.Crs = strings.ToLower(value)
+		case "u":
+			if i > 1 || (i == 1 && !found["crs"]) {
+				return nil, errGeoParameterOrder
+			}
+			if !isGeoNum(value) || value[0] == '-' {
+				return nil, errUvalNotFound
+			}
+			uncertainty, _ := strconv.ParseFloat(value, 64)
+			geo.Uncertainty = &uncertainty
+		default:
+			decoded, err := decodePctEncoded([]byte(value))
+			if err != nil {
+				return nil, err
+			}
+			if hasValue && len(decoded) == 0 {
+				return nil, errGeoParameterNotFound
+			}
+			geo.Params = append(geo.Params, GeoParam{Name: name, Value: string(decoded)})
+		}
+	}
+
+	// RFC5870 - 3.4.2. Component Description for WGS-84
+	if geo.Crs == "wgs84" {
+		if geo.CoordA < -90 || geo.CoordA > 90 {
+			return nil, errLatitudeOutOfRange
+		}
+		if geo.CoordB < -180 || geo.CoordB > 180 {
+			return nil, errLongitudeOutOfRange
+		}
+	}
+	return geo, nil
+}
+
+// Equivalent reports whether geo and other identify the same location as
+// RFC5870 - 3.4.4. URI Comparison says. The coordinates are compared as
+// numbers, so "-0" equals "0". For "wgs84", the longitude is ignored at the
+// poles and the longitude 180 equals -180. A missing coord-c or u is not
+// equal to any value of them. The other parameters are compared regardless
+// of their order, case-insensitively.
+func (geo *Geo) Equivalent(other *Geo) bool {
+	if geo.Crs != other.Crs ||
+		geo.CoordA != other.CoordA ||
+		!equalGeoNum(geo.CoordC, other.CoordC) ||
+		!equalGeoNum(geo.Uncertainty, other.Uncertainty) ||
+		len(geo.Params) != len(other.Params) {
+		return false
+	}
+	if geo.Crs == "wgs84" {
+		if math.Abs(geo.CoordA) != 90 &&
+			geo.CoordB != other.CoordB &&
+			!(math.Abs(geo.CoordB) == 180 && math.Abs(other.CoordB) == 180) {
+			return false
+		}
+	} else if geo.CoordB != other.CoordB {
+		return false
+	}
+	params := map[string]string{}
+	for _, param := range geo.Params {
+		params[param.Name] = param.Value
+	}
+	for _, param := range other.Params {
+		value, found := params[param.Name]
+		if !found || !strings.EqualFold(value, param.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// String encodes the geo URI. crs is omitted if it is "wgs84".
+func (geo *Geo) String() string {
+	str := "geo:" + formatGeoNum(geo.CoordA) + "," + formatGeoNum(geo.CoordB)
+	if geo.CoordC != nil {
+		str += "," + formatGeoNum(*geo.CoordC)
+	}
+	if geo.Crs != "" && geo.Crs != "wgs84" {
+		str += ";crs=" + geo.Crs
+	}
+	if geo.Uncertainty != nil {
+		str += ";u=" + formatGeoNum(*geo.Uncertainty)
+	}
+	for _, param := range geo.Params {
+		str += ";" + param.Name
+		if param.Value != "" {
+			str += "=" + string(encodePctEncoded([]byte(param.Value), isGeoParamchar))
+		}
+	}
+	return str
+}
+
+// isGeoNum reports whether str is num.
+func isGeoNum(str string) bool {
+	integer, fraction, found := strings.Cut(strings.TrimPrefix(str, "-"), ".")
+	return isDigits(integer) && (!found || isDigits(fraction))
+}
+
+func formatGeoNum(num float64) string {
+	if num == 0 {
+		// "-0" is the same as "0".
+		num = 0
+	}
+	return strconv.FormatFloat(num, 'f', -1, 64)
+}
+
+func equalGeoNum(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// RFC5870 - 3.3. URI Scheme Syntax
+//
+//	paramchar     = p-unreserved / unreserved / pct-encoded
+//	p-unreserved  = "[" / "]" / ":" / "&" / "+" / "$"
+//
+// "[" and "]" are pct-encoded, since RFC3986 does not allow them in path.
+
+func isGeoParamchar(c byte) bool {
+	return isUnreserved(c) || strings.IndexByte(":&+$", c) >= 0
+}
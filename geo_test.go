@@ -0,0 +1,196 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseGeo(t *testing.T) {
+	type TestCase struct {
+		testName            string
+		data                []byte
+		expectedString      string
+		expectedCrs         string
+		expectedCoordA      float64
+		expectedCoordB      float64
+		expectedCoordC      string
+		expectedUncertainty string
+		expectedParams      []GeoParam
+		expectedErr         error
+	}
+
+	tests := []TestCase{
+		// RFC5870 - 6.2. Examples
+		{
+			testName:            "data: []byte(\"geo:13.4125,103.8667\")",
+			data:                []byte("geo:13.4125,103.8667"),
+			expectedString:      "geo:13.4125,103.8667",
+			expectedCrs:         "wgs84",
+			expectedCoordA:      13.4125,
+			expectedCoordB:      103.8667,
+			expectedCoordC:      "<nil>",
+			expectedUncertainty: "<nil>",
+			expectedParams:      []GeoParam{},
+		},
+		{
+			testName:            "data: []byte(\"geo:48.2010,16.3695,183\")",
+			data:                []byte("geo:48.2010,16.3695,183"),
+			expectedString:      "geo:48.201,16.3695,183",
+			expectedCrs:         "wgs84",
+			expectedCoordA:      48.201,
+			expectedCoordB:      16.3695,
+			expectedCoordC:      "183",
+			expectedUncertainty: "<nil>",
+			expectedParams:      []GeoParam{},
+		},
+		{
+			testName:            "data: []byte(\"geo:48.198634,16.371648;crs=WGS84;u=40\")",
+			data:                []byte("geo:48.198634,16.371648;crs=WGS84;u=40"),
+			expectedString:      "geo:48.198634,16.371648;u=40",
+			expectedCrs:         "wgs84",
+			expectedCoordA:      48.198634,
+			expectedCoordB:      16.371648,
+			expectedCoordC:      "<nil>",
+			expectedUncertainty: "40",
+			expectedParams:      []GeoParam{},
+		},
+		{
+			testName:            "data: []byte(\"geo:-0,200;crs=local;Name=a%20b;flag\")",
+			data:                []byte("geo:-0,200;crs=local;Name=a%20b;flag"),
+			expectedString:      "geo:0,200;crs=local;name=a%20b;flag",
+			expectedCrs:         "local",
+			expectedCoordA:      0,
+			expectedCoordB:      200,
+			expectedCoordC:      "<nil>",
+			expectedUncertainty: "<nil>",
+			expectedParams:      []GeoParam{{Name: "name", Value: "a b"}, {Name: "flag"}},
+		},
+		{
+			testName:    "data: []byte(\"geo://1,2\")",
+			data:        []byte("geo://1,2"),
+			expectedErr: errGeoAuthority,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2?q\")",
+			data:        []byte("geo:1,2?q"),
+			expectedErr: errGeoQueryOrFragment,
+		},
+		{
+			testName:    "data: []byte(\"geo:1\")",
+			data:        []byte("geo:1"),
+			expectedErr: errCoordinatesNotFound,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2,3,4\")",
+			data:        []byte("geo:1,2,3,4"),
+			expectedErr: errCoordinatesNotFound,
+		},
+		{
+			testName:    "data: []byte(\"geo:1.,2\")",
+			data:        []byte("geo:1.,2"),
+			expectedErr: errCoordinatesNotFound,
+		},
+		{
+			testName:    "data: []byte(\"geo:+1,2\")",
+			data:        []byte("geo:+1,2"),
+			expectedErr: errCoordinatesNotFound,
+		},
+		{
+			testName:    "data: []byte(\"geo:90.1,2\")",
+			data:        []byte("geo:90.1,2"),
+			expectedErr: errLatitudeOutOfRange,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,-180.5\")",
+			data:        []byte("geo:1,-180.5"),
+			expectedErr: errLongitudeOutOfRange,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2;u=-1\")",
+			data:        []byte("geo:1,2;u=-1"),
+			expectedErr: errUvalNotFound,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2;u=1;crs=wgs84\")",
+			data:        []byte("geo:1,2;u=1;crs=wgs84"),
+			expectedErr: errGeoParameterOrder,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2;a=b;u=1\")",
+			data:        []byte("geo:1,2;a=b;u=1"),
+			expectedErr: errGeoParameterOrder,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2;a;A\")",
+			data:        []byte("geo:1,2;a;A"),
+			expectedErr: errGeoParameterDuplicate,
+		},
+		{
+			testName:    "data: []byte(\"geo:1,2;crs=\")",
+			data:        []byte("geo:1,2;crs="),
+			expectedErr: errCrsLabelNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			geo, err := ParseGeo(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(Crs)", t, testCase.expectedCrs, geo.Crs)
+			equals(testCase.testName+"(CoordA)", t, testCase.expectedCoordA, geo.CoordA)
+			equals(testCase.testName+"(CoordB)", t, testCase.expectedCoordB, geo.CoordB)
+			equals(testCase.testName+"(CoordC)", t, testCase.expectedCoordC, formatGeoNumPtr(geo.CoordC))
+			equals(testCase.testName+"(Uncertainty)", t, testCase.expectedUncertainty, formatGeoNumPtr(geo.Uncertainty))
+			sliceHasSameElem(testCase.testName+"(Params)", t, testCase.expectedParams, geo.Params)
+			equals(testCase.testName+"(String)", t, testCase.expectedString, geo.String())
+		})
+	}
+}
+
+func formatGeoNumPtr(num *float64) string {
+	if num == nil {
+		return "<nil>"
+	}
+	return formatGeoNum(*num)
+}
+
+func TestGeoEquivalent(t *testing.T) {
+	type TestCase struct {
+		data1    string
+		data2    string
+		expected bool
+	}
+
+	tests := []TestCase{
+		// RFC5870 - 6.4. URI Comparison
+		{data1: "geo:90,-22.43;crs=WGS84", data2: "geo:90,46", expected: true},
+		{data1: "geo:22.300;crs=WGS84,-118.44", data2: "geo:22.3,-118.4400", expected: false},
+		{data1: "geo:66,30;u=6.500;FOo=this%2dthat", data2: "geo:66.0,30;u=6.5;foo=this-that", expected: true},
+		{data1: "geo:70,20;foo=1.00;bar=white", data2: "geo:70,20;foo=1;bar=white", expected: false},
+		{data1: "geo:47,11;foo=blue;bar=white", data2: "geo:47,11;bar=white;foo=blue", expected: true},
+		{data1: "geo:22,0;bar=blue", data2: "geo:22,0;BAR=blue", expected: true},
+		{data1: "geo:-90,10", data2: "geo:-90,-170", expected: true},
+		{data1: "geo:10,180", data2: "geo:10,-180", expected: true},
+		{data1: "geo:-0,0", data2: "geo:0,0", expected: true},
+		{data1: "geo:1,2,3", data2: "geo:1,2", expected: false},
+		{data1: "geo:1,2;u=0", data2: "geo:1,2", expected: false},
+		{data1: "geo:90,10;crs=local", data2: "geo:90,20;crs=local", expected: false},
+	}
+
+	for _, testCase := range tests {
+		testName := fmt.Sprintf("%v, %v", testCase.data1, testCase.data2)
+		t.Run(testName, func(t *testing.T) {
+			geo1, err1 := ParseGeo([]byte(testCase.data1))
+			geo2, err2 := ParseGeo([]byte(testCase.data2))
+			if err1 != nil || err2 != nil {
+				// geo:22.300;crs=WGS84,-118.44 is not a valid geo URI.
+				equals(testName, t, testCase.expected, false)
+				return
+			}
+			equals(testName, t, testCase.expected, geo1.Equivalent(geo2))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	errGitTransportNotFound  = errors.New("transport of git remote not found.")
+	errGitHostNotFound       = errors.New("host of git remote not found.")
+	errGitUserInfo           = errors.New("git remote of git transport cannot have userinfo.")
+	errGitQuery              = errors.New("git remote cannot have query or fragment.")
+	errGitRepositoryNotFound = errors.New("repository of git remote not found.")
+)
+
+// GitTransport is the transport protocol of a git remote.
+type GitTransport int
+
+const (
+	GitTransportSsh GitTransport = iota
+	GitTransportGit
+	GitTransportHttp
+	GitTransportHttps
+	GitTransportFile
+)
+
+func (transport GitTransport) String() string {
+	switch transport {
+	case GitTransportGit:
+		return "git"
+	case GitTransportHttp:
+		return "http"
+	case GitTransportHttps:
+		return "https"
+	case GitTransportFile:
+		return "file"
+	}
+	return "ssh"
+}
+
+// GitRemote is a git remote normalized from any of the forms. All the fields
+// are decoded.
+type GitRemote struct {
+	Transport GitTransport
+	Scp       bool   // true if the remote is written in the SCP-like syntax
+	User      string // "" if the user is omitted
+	Host      string // in lowercase, "" for a local repository
+	Port      string // "" if the port is omitted
+	Path      string // as written, relative to the home directory if it has no leading "/"
+	Owner     string // the path before the repository name, such as "org" or "group/subgroup"
+	Repo      string // the repository name without ".git"
+}
+
+// ParseGitRemote parses a git remote in one of the following forms of
+// git-clone(1) - GIT URLS.
+//
+//	ssh://[user@]host.xz[:port]/path/to/repo.git/
+//	git://host.xz[:port]/path/to/repo.git/
+//	http[s]://host.xz[:port]/path/to/repo.git/
+//	[user@]host.xz:path/to/repo.git/
+//	file:///path/to/repo.git/
+//
+// "git+ssh" and "ssh+git" are also accepted as ssh. A password in the userinfo
+// of http and https is dropped.
+//
+// NOTE
+// The SCP-like syntax is not a URI, so Parse is used only when data has "://".
+// As git does, data is the SCP-like syntax if it has ":" before the first "/".
+func ParseGitRemote(data []byte) (*GitRemote, error) {
+	var remote *GitRemote
+	var err error
+	if bytes.Contains(data, []byte("://")) {
+		remote, err = parseGitUrl(data)
+	} else {
+		remote, err = parseGitScp(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSuffix(strings.Trim(remote.Path, "/"), ".git")
+	slash := strings.LastIndexByte(trimmed, '/')
+	if slash >= 0 {
+		remote.Owner = trimmed[:slash]
+	}
+	remote.Repo = trimmed[slash+1:]
+	if remote.Repo == "" {
+		return nil, errGitRepositoryNotFound
+	}
+	return remote, nil
+}
+
+// Equivalent reports whether the remotes refer to the same repository on the
+// same host, regardless of the transport, the user and the port.
+func (remote *GitRemote) Equivalent(other *GitRemote) bool {
+	return remote.Host == other.Host && remote.Owner == other.Owner && remote.Repo == other.Repo
+}
+
+func parseGitUrl(data []byte) (*GitRemote, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	remote := &GitRemote{}
+	switch strings.ToLower(string(uri.Scheme)) {
+	case "ssh", "git+ssh", "ssh+git":
+		remote.Transport = GitTransportSsh
+	case "git":
+		remote.Transport = GitTransportGit
+	case "http":
+		remote.Transport = GitTransportHttp
+	case "https":
+		remote.Transport = GitTransportHttps
+	case "file":
+		fileUri, err := ParseFileUri(data)
+		if err != nil {
+			return nil, err
+		}
+		remote.Transport = GitTransportFile
+		remote.Host = strings.ToLower(fileUri.Host)
+		remote.Path = fileUri.Path
+		return remote, nil
+	default:
+		return nil, errGitTransportNotFound
+	}
+	if len(uri.DoubleSlash) == 0 || len(uri.Host) == 0 {
+		return nil, errGitHostNotFound
+	}
+	if len(uri.Question) > 0 || len(uri.Sharp) > 0 {
+		return nil, errGitQuery
+	}
+	if len(uri.AtSign) > 0 {
+		if remote.Transport == GitTransportGit {
+			return nil, errGitUserInfo
+		}
+		user, _, _ := bytes.Cut(uri.UserInfo, []byte(":"))
+		decoded, err := decodePctEncoded(user)
+		if err != nil {
+			return nil, err
+		}
+		remote.User = string(decoded)
+	}
+	host, err := decodePctEncoded(uri.Host)
+	if err != nil {
+		return nil, err
+	}
+	remote.Host = strings.ToLower(string(host))
+	remote.Port = string(uri.Port)
+	path, err := decodePctEncoded(uri.Path)
+	if err != nil {
+		return nil, err
+	}
+	remote.Path = string(path)
+	return remote, nil
+}
+
+// parseGitScp parses the SCP-like syntax.
+//
+//	[user@]host:path
+//
+// The host may be an IPv6address enclosed in "[" and "]".
+func parseGitScp(data []byte) (*GitRemote, error) {
+	remote := &GitRemote{Transport: GitTransportSsh, Scp: true}
+	str := string(data)
+	if at := strings.IndexByte(str, '@'); at >= 0 && !strings.ContainsAny(str[:at], ":/") {
+		remote.User, str = str[:at], str[at+1:]
+	}
+
+	host := ""
+	if strings.HasPrefix(str, "[") {
+		end := strings.Index(str, "]:")
+		if end < 0 || !isIpV6Address([]byte(str[1:end])) {
+			return nil, errGitHostNotFound
+		}
+		host, remote.Path = str[:end+1], str[end+2:]
+	} else {
+		var found bool
+		host, remote.Path, found = strings.Cut(str, ":")
+		if !found || strings.IndexByte(host, '/') >= 0 {
+			return nil, errGitTransportNotFound
+		}
+		if host == "" || scanClass([]byte(host), 0, classRegName) != len(host) {
+			return nil, errGitHostNotFound
+		}
+	}
+	remote.Host = strings.ToLower(host)
+	return remote, nil
+}
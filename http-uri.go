@@ -0,0 +1,109 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+)
+
+var (
+	errHttpSchemeNotFound = errors.New("http or https scheme not found.")
+	errHttpHostNotFound   = errors.New("host of http URI not found.")
+	errHttpUserInfo       = errors.New("http URI cannot have userinfo.")
+	errHostFieldNotFound  = errors.New("uri-host of Host field not found.")
+)
+
+// HttpUri is an http or https URI.
+type HttpUri struct {
+	Uri
+}
+
+// ParseHttpUri parses an http or https URI.
+//
+// RFC9110 - 4.2.1. http URI Scheme
+//
+//	http-URI = "http" "://" authority path-abempty [ "?" query ]
+//
+// RFC9110 - 4.2.2. https URI Scheme
+//
+//	https-URI = "https" "://" authority path-abempty [ "?" query ]
+//
+// A sender MUST NOT generate an "http" URI with an empty host identifier.
+// A recipient that processes such a URI reference MUST reject it as invalid.
+//
+// RFC9110 - 4.2.4. Deprecation of userinfo in http(s) URIs
+// Before making use of an "http" or "https" URI reference received from an
+// untrusted source, a recipient SHOULD parse for userinfo and treat its
+// presence as an error.
+//
+// A fragment is accepted, since it is allowed in a URI reference.
+func ParseHttpUri(data []byte) (*HttpUri, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("http")) && !bytes.EqualFold(uri.Scheme, []byte("https")) {
+		return nil, errHttpSchemeNotFound
+	}
+	if len(uri.DoubleSlash) == 0 || len(uri.Host) == 0 {
+		return nil, errHttpHostNotFound
+	}
+	if len(uri.AtSign) > 0 {
+		return nil, errHttpUserInfo
+	}
+	return &HttpUri{Uri: *uri}, nil
+}
+
+// EffectiveRequestUri reconstructs the target URI of a request from the
+// request-target, whether the connection is secured, and the value of the
+// Host header field. host is not used for absolute-form.
+//
+// RFC9112 - 3.3. Reconstructing the Target URI
+//
+//	If the request-target is in absolute-form, the target URI is the same as
+//	the request-target. Otherwise, the target URI is constructed as follows:
+//	  - scheme is "https" if the request is received over a secured
+//	    connection, otherwise "http"
+//	  - authority is the request-target of authority-form, otherwise the
+//	    Host header field value
+//	  - path and query are those of origin-form, otherwise empty
+func EffectiveRequestUri(target *RequestTarget, secure bool, host []byte) (*HttpUri, error) {
+	if target.Form == AbsoluteForm {
+		return ParseHttpUri([]byte(target.String()))
+	}
+	scheme := "http://"
+	if secure {
+		scheme = "https://"
+	}
+	if target.Form == AuthorityForm {
+		return ParseHttpUri([]byte(scheme + target.String()))
+	}
+
+	authority, err := ParseHttpUri([]byte(scheme + string(host)))
+	if err != nil {
+		return nil, err
+	}
+	if len(authority.Path) > 0 || len(authority.Question) > 0 || len(authority.Sharp) > 0 {
+		return nil, errHostFieldNotFound
+	}
+	if target.Form == AsteriskForm {
+		return authority, nil
+	}
+	return ParseHttpUri([]byte(scheme + string(host) + target.String()))
+}
+
+// Secure reports whether the scheme is https.
+func (uri *HttpUri) Secure() bool {
+	return bytes.EqualFold(uri.Scheme, []byte("https"))
+}
+
+// TargetOrigin returns the origin of the target resource as RFC9110 - 4.3.1
+// defines, serialized with the port always present, such as
+// "https://example.com:443". It is empty if uri has no origin, such as
+// the zero value.
+func (uri *HttpUri) TargetOrigin() string {
+	origin, found := tupleOrigin(&uri.Uri)
+	if !found {
+		return ""
+	}
+	return origin.Scheme + "://" + origin.Host + ":" + origin.Port
+}
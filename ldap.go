@@ -0,0 +1,314 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	errLdapSchemeNotFound    = errors.New("ldap or ldaps scheme not found.")
+	errLdapAuthorityNotFound = errors.New("authority of LDAP URL not found.")
+	errLdapUserInfo          = errors.New("LDAP URL cannot have userinfo.")
+	errLdapFragment          = errors.New("LDAP URL cannot have fragment.")
+	errLdapTooManyFields     = errors.New("too many fields in query of LDAP URL.")
+	errAttrDescNotFound      = errors.New("attrdesc not found.")
+	errScopeNotFound         = errors.New("scope not found.")
+	errFilterNotFound        = errors.New("filter not found.")
+	errExtensionTypeNotFound = errors.New("extype not found.")
+)
+
+// LdapScope is the scope of the search of an LDAP URL.
+type LdapScope int
+
+const (
+	LdapScopeBase LdapScope = iota
+	LdapScopeOne
+	LdapScopeSub
+)
+
+func (scope LdapScope) String() string {
+	switch scope {
+	case LdapScopeOne:
+		return "one"
+	case LdapScopeSub:
+		return "sub"
+	}
+	return "base"
+}
+
+// LdapDefaultFilter is the filter used if it is omitted.
+const LdapDefaultFilter = "(objectClass=*)"
+
+// LdapUri is an LDAP URL. All the fields are decoded.
+type LdapUri struct {
+	Secure     bool   // true if the scheme is ldaps
+	Host       string // "" if the host is omitted
+	Port       string // "" if the port is omitted
+	Dn         string
+	Attributes []string // empty if all user attributes are requested
+	Scope      LdapScope
+	Filter     string
+	Extensions []LdapExtension
+}
+
+type LdapExtension struct {
+	Critical bool   // true if the extension is prefixed with "!"
+	Type     string // extype
+	Value    string // exvalue, "" if it is omitted
+}
+
+// ParseLdapUri parses an LDAP URL. The omitted scope and filter are the
+// defaults, base and "(objectClass=*)".
+//
+// RFC4516 - 2. URL Definition
+//
+//	ldapurl     = scheme COLON SLASH SLASH [host [COLON port]]
+//	                 [SLASH dn [QUESTION [attributes]
+//	                 [QUESTION [scope] [QUESTION [filter]
+//	                 [QUESTION extensions]]]]]
+//	scheme      = "ldap"
+//	dn          = distinguishedName ; From Section 3 of [RFC4514]
+//	attributes  = attrdesc *(COMMA attrdesc)
+//	attrdesc    = selector *(COMMA selector)
+//	scope       = "base" / "one" / "sub"
+//	filter      = filter ; From Section 2 of [RFC4515]
+//	extensions  = extension *(COMMA extension)
+//	extension   = [EXCLAMATION] extype [EQUALS exvalue]
+//
+// "ldaps" is also accepted for LDAP over TLS.
+//
+// NOTE
+// Parse takes all the fields after the first "?" as query. So the query is
+// split into the fields. "," in attrdesc and exvalue is pct-encoded, so the
+// fields are split before decoding.
+func ParseLdapUri(data []byte) (*LdapUri, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	ldap := &LdapUri{Filter: LdapDefaultFilter}
+	switch {
+	case bytes.EqualFold(uri.Scheme, []byte("ldap")):
+	case bytes.EqualFold(uri.Scheme, []byte("ldaps")):
+		ldap.Secure = true
+	default:
+		return nil, errLdapSchemeNotFound
+	}
+	if len(uri.DoubleSlash) == 0 {
+		return nil, errLdapAuthorityNotFound
+	}
+	if len(uri.AtSign) > 0 {
+		return nil, errLdapUserInfo
+	}
+	if len(uri.Sharp) > 0 {
+		return nil, errLdapFragment
+	}
+	host, err := decodePctEncoded(uri.Host)
+	if err != nil {
+		return nil, err
+	}
+	ldap.Host = string(host)
+	ldap.Port = string(uri.Port)
+
+	// [SLASH dn]
+	if len(uri.Path) > 0 {
+		dn, err := decodePctEncoded(uri.Path[1:])
+		if err != nil {
+			return nil, err
+		}
+		ldap.Dn = string(dn)
+	}
+	if len(uri.Question) == 0 {
+		return ldap, nil
+	}
+
+	fields := bytes.Split(uri.Query, []byte("?"))
+	if len(fields) > 4 {
+		return nil, errLdapTooManyFields
+	}
+	for len(fields) < 4 {
+		fields = append(fields, nil)
+	}
+
+	// [attributes]
+	if len(fields[0]) > 0 {
+		for _, encoded := range bytes.Split(fields[0], []byte(",")) {
+			attribute, err := decodePctEncoded(encoded)
+			if err != nil {
+				return nil, err
+			}
+			if !isAttrDesc(attribute) {
+				return nil, errAttrDescNotFound
+			}
+			ldap.Attributes = append(ldap.Attributes, string(attribute))
+		}
+	}
+
+	// [scope]
+	scope, err := decodePctEncoded(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(string(scope)) {
+	case "", "base":
+		ldap.Scope = LdapScopeBase
+	case "one":
+		ldap.Scope = LdapScopeOne
+	case "sub":
+		ldap.Scope = LdapScopeSub
+	default:
+		return nil, errScopeNotFound
+	}
+
+	// [filter]
+	if len(fields[2]) > 0 {
+		filter, err := decodePctEncoded(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		if !isLdapFilter(filter) {
+			return nil, errFilterNotFound
+		}
+		ldap.Filter = string(filter)
+	}
+
+	// [extensions]
+	if len(fields[3]) > 0 {
+		for _, encoded := range bytes.Split(fields[3], []byte(",")) {
+			extension := LdapExtension{}
+			if len(encoded) > 0 && encoded[0] == '!' {
+				extension.Critical = true
+				encoded = encoded[1:]
+			}
+			exType, exValue, _ := bytes.Cut(encoded, []byte("="))
+			decodedType, err := decodePctEncoded(exType)
+			if err != nil {
+				return nil, err
+			}
+			decodedValue, err := decodePctEncoded(exValue)
+			if err != nil {
+				return nil, err
+			}
+			if len(decodedType) == 0 {
+				return nil, errExtensionTypeNotFound
+			}
+			extension.Type = string(decodedType)
+			extension.Value = string(decodedValue)
+			ldap.Extensions = append(ldap.Extensions, extension)
+		}
+	}
+	return ldap, nil
+}
+
+// String encodes the LDAP URL. The trailing fields with the default values
+// are omitted.
+func (ldap *LdapUri) String() string {
+	str := "ldap://"
+	if ldap.Secure {
+		str = "ldaps://"
+	}
+	str += string(encodePctEncoded([]byte(ldap.Host), func(c byte) bool {
+		return hasClass(c, classRegName) || c == ':' || c == '[' || c == ']'
+	}))
+	if ldap.Port != "" {
+		str += ":" + ldap.Port
+	}
+
+	fields := []string{}
+	encodeField := func(field string) string {
+		return string(encodePctEncoded([]byte(field), func(c byte) bool {
+			return c != ',' && (c == '/' || hasClass(c, classPchar))
+		}))
+	}
+	attributes := []string{}
+	for _, attribute := range ldap.Attributes {
+		attributes = append(attributes, encodeField(attribute))
+	}
+	fields = append(fields, strings.Join(attributes, ","))
+	if ldap.Scope != LdapScopeBase {
+		fields = append(fields, ldap.Scope.String())
+	} else {
+		fields = append(fields, "")
+	}
+	if ldap.Filter != LdapDefaultFilter {
+		fields = append(fields, encodeField(ldap.Filter))
+	} else {
+		fields = append(fields, "")
+	}
+	extensions := []string{}
+	for _, extension := range ldap.Extensions {
+		encoded := encodeField(extension.Type)
+		if extension.Critical {
+			encoded = "!" + encoded
+		}
+		if extension.Value != "" {
+			encoded += "=" + encodeField(extension.Value)
+		}
+		extensions = append(extensions, encoded)
+	}
+	fields = append(fields, strings.Join(extensions, ","))
+	for len(fields) > 0 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+
+	if ldap.Dn != "" || len(fields) > 0 {
+		str += "/" + string(encodePctEncoded([]byte(ldap.Dn), func(c byte) bool {
+			return c == '/' || hasClass(c, classPchar)
+		}))
+	}
+	if len(fields) > 0 {
+		str += "?" + strings.Join(fields, "?")
+	}
+	return str
+}
+
+// RFC4512 - 2.5. Attribute Descriptions
+//
+//	attributedescription = attributetype options
+//	attributetype = oid
+//	options = *( SEMI option )
+//	option = 1*keychar
+//
+// "*" of RFC4511 - 4.5.1.8 and "+" of RFC3673 are also accepted.
+
+func isAttrDesc(attribute []byte) bool {
+	if string(attribute) == "*" || string(attribute) == "+" {
+		return true
+	}
+	for i, option := range bytes.Split(attribute, []byte(";")) {
+		if len(option) == 0 {
+			return false
+		}
+		for _, c := range option {
+			if !isAlpha(c) && !isDigit(c) && c != '-' && !(i == 0 && c == '.') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isLdapFilter reports whether filter is enclosed in parentheses which are
+// balanced. The escaped parentheses such as "\28" are not counted.
+func isLdapFilter(filter []byte) bool {
+	if len(filter) < 2 || filter[0] != '(' || filter[len(filter)-1] != ')' {
+		return false
+	}
+	depth := 0
+	for i, c := range filter {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(filter)-1 {
+				return false
+			}
+		}
+		if depth < 0 {
+			return false
+		}
+	}
+	return depth == 0
+}
@@ -0,0 +1,153 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseLdapUri(t *testing.T) {
+	type TestCase struct {
+		testName       string
+		data           []byte
+		expectedLdap   LdapUri
+		expectedString string
+		expectedErr    error
+	}
+
+	tests := []TestCase{
+		// RFC4516 - 4. Examples
+		{
+			testName:       "data: []byte(\"ldap:///o=University%20of%20Michigan,c=US\")",
+			data:           []byte("ldap:///o=University%20of%20Michigan,c=US"),
+			expectedLdap:   LdapUri{Dn: "o=University of Michigan,c=US", Filter: LdapDefaultFilter},
+			expectedString: "ldap:///o=University%20of%20Michigan,c=US",
+		},
+		{
+			testName: "data: []byte(\"ldap://ldap1.example.net/o=University%20of%20Michigan,c=US?postalAddress\")",
+			data:     []byte("ldap://ldap1.example.net/o=University%20of%20Michigan,c=US?postalAddress"),
+			expectedLdap: LdapUri{
+				Host: "ldap1.example.net", Dn: "o=University of Michigan,c=US",
+				Attributes: []string{"postalAddress"}, Filter: LdapDefaultFilter,
+			},
+			expectedString: "ldap://ldap1.example.net/o=University%20of%20Michigan,c=US?postalAddress",
+		},
+		{
+			testName: "data: []byte(\"ldap://ldap1.example.net:6666/o=University%20of%20Michigan,c=US??sub?(cn=Babs%20Jensen)\")",
+			data:     []byte("ldap://ldap1.example.net:6666/o=University%20of%20Michigan,c=US??sub?(cn=Babs%20Jensen)"),
+			expectedLdap: LdapUri{
+				Host: "ldap1.example.net", Port: "6666", Dn: "o=University of Michigan,c=US",
+				Scope: LdapScopeSub, Filter: "(cn=Babs Jensen)",
+			},
+			expectedString: "ldap://ldap1.example.net:6666/o=University%20of%20Michigan,c=US??sub?(cn=Babs%20Jensen)",
+		},
+		{
+			testName: "data: []byte(\"LDAP://ldap1.example.com/c=GB?objectClass?ONE\")",
+			data:     []byte("LDAP://ldap1.example.com/c=GB?objectClass?ONE"),
+			expectedLdap: LdapUri{
+				Host: "ldap1.example.com", Dn: "c=GB",
+				Attributes: []string{"objectClass"}, Scope: LdapScopeOne, Filter: LdapDefaultFilter,
+			},
+			expectedString: "ldap://ldap1.example.com/c=GB?objectClass?one",
+		},
+		{
+			testName: "data: []byte(\"ldap://ldap2.example.com/o=Question%3f,c=US?mail\")",
+			data:     []byte("ldap://ldap2.example.com/o=Question%3f,c=US?mail"),
+			expectedLdap: LdapUri{
+				Host: "ldap2.example.com", Dn: "o=Question?,c=US",
+				Attributes: []string{"mail"}, Filter: LdapDefaultFilter,
+			},
+			expectedString: "ldap://ldap2.example.com/o=Question%3F,c=US?mail",
+		},
+		{
+			testName: "data: []byte(\"ldap://ldap3.example.com/o=Babsco,c=US???(four-octet=%5c00%5c00%5c00%5c04)\")",
+			data:     []byte("ldap://ldap3.example.com/o=Babsco,c=US???(four-octet=%5c00%5c00%5c00%5c04)"),
+			expectedLdap: LdapUri{
+				Host: "ldap3.example.com", Dn: "o=Babsco,c=US", Filter: "(four-octet=\\00\\00\\00\\04)",
+			},
+			expectedString: "ldap://ldap3.example.com/o=Babsco,c=US???(four-octet=%5C00%5C00%5C00%5C04)",
+		},
+		{
+			testName: "data: []byte(\"ldap:///??sub??!e-bindname=cn=Manager%2cdc=example%2cdc=com,x=1\")",
+			data:     []byte("ldap:///??sub??!e-bindname=cn=Manager%2cdc=example%2cdc=com,x=1"),
+			expectedLdap: LdapUri{
+				Scope: LdapScopeSub, Filter: LdapDefaultFilter,
+				Extensions: []LdapExtension{
+					{Critical: true, Type: "e-bindname", Value: "cn=Manager,dc=example,dc=com"},
+					{Type: "x", Value: "1"},
+				},
+			},
+			expectedString: "ldap:///??sub??!e-bindname=cn=Manager%2Cdc=example%2Cdc=com,x=1",
+		},
+		{
+			testName: "data: []byte(\"ldaps://[::1]:636/?cn;lang-en,*\")",
+			data:     []byte("ldaps://[::1]:636/?cn;lang-en,*"),
+			expectedLdap: LdapUri{
+				Secure: true, Host: "[::1]", Port: "636",
+				Attributes: []string{"cn;lang-en", "*"}, Filter: LdapDefaultFilter,
+			},
+			expectedString: "ldaps://[::1]:636/?cn;lang-en,*",
+		},
+		{
+			testName:    "data: []byte(\"http://example.com/\")",
+			data:        []byte("http://example.com/"),
+			expectedErr: errLdapSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ldap:o=a\")",
+			data:        []byte("ldap:o=a"),
+			expectedErr: errLdapAuthorityNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ldap://u@example.com/\")",
+			data:        []byte("ldap://u@example.com/"),
+			expectedErr: errLdapUserInfo,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/#f\")",
+			data:        []byte("ldap://example.com/#f"),
+			expectedErr: errLdapFragment,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/?a?base?(a=b)?x?y\")",
+			data:        []byte("ldap://example.com/?a?base?(a=b)?x?y"),
+			expectedErr: errLdapTooManyFields,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/?a%20b\")",
+			data:        []byte("ldap://example.com/?a%20b"),
+			expectedErr: errAttrDescNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/??subtree\")",
+			data:        []byte("ldap://example.com/??subtree"),
+			expectedErr: errScopeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/???(a=b\")",
+			data:        []byte("ldap://example.com/???(a=b"),
+			expectedErr: errFilterNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/???(a=b)(c=d)\")",
+			data:        []byte("ldap://example.com/???(a=b)(c=d)"),
+			expectedErr: errFilterNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ldap://example.com/????!=1\")",
+			data:        []byte("ldap://example.com/????!=1"),
+			expectedErr: errExtensionTypeNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			ldap, err := ParseLdapUri(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName, t, fmt.Sprintf("%+v", testCase.expectedLdap), fmt.Sprintf("%+v", *ldap))
+			equals(testCase.testName+"(String)", t, testCase.expectedString, ldap.String())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package urip
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMagnetSchemeNotFound = errors.New("magnet scheme not found.")
+	errMagnetHierPart       = errors.New("magnet link cannot have authority or path.")
+	errMagnetParamNotFound  = errors.New("parameter of magnet link not found.")
+	errMagnetXtNotFound     = errors.New("xt of magnet link not found.")
+	errBtihNotFound         = errors.New("info hash of btih not found.")
+	errBtmhNotFound         = errors.New("multihash of btmh not found.")
+	errExactLengthNotFound  = errors.New("xl of magnet link not found.")
+	errSelectOnlyNotFound   = errors.New("so of magnet link not found.")
+)
+
+// Magnet is a magnet link. All the fields are decoded.
+type Magnet struct {
+	ExactTopics []MagnetTopic // xt
+	DisplayName string        // dn
+	ExactLength int64         // xl, 0 if it is omitted
+	Trackers    []string      // tr
+	WebSeeds    []string      // ws
+	SelectOnly  []MagnetRange // so
+	Params      []MagnetParam // parameters other than the above
+}
+
+// MagnetTopic is an exact topic, which is a URN such as "urn:btih:<hash>".
+type MagnetTopic struct {
+	Urn
+	// Hash is the info hash of btih, or the multihash of btmh. It is nil for
+	// the other NIDs.
+	Hash []byte
+}
+
+// MagnetRange is a range of the file indices, First and Last inclusive.
+type MagnetRange struct {
+	First int
+	Last  int
+}
+
+type MagnetParam struct {
+	Name  string
+	Value string
+}
+
+// ParseMagnet parses a magnet link.
+//
+// BEP 9 - Magnet URI format
+//
+//	magnet:?xt=urn:btih:<info-hash>&dn=<name>&tr=<tracker-url>&x.pe=<peer-address>
+//
+// BEP 19 - WebSeed and BEP 53 - Magnet URI extension - Select specific file
+// indices for download
+//
+//	ws=<webseed-url>
+//	so=0,2,4,6-8
+//
+// The info hash of btih is 40 hex digits or 32 base32 digits. The multihash
+// of btmh of BEP 52 is a hex encoded SHA-256 multihash. xt, tr and ws may
+// appear more than once, and may have an index such as "xt.1".
+func ParseMagnet(data []byte) (*Magnet, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("magnet")) {
+		return nil, errMagnetSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 || len(uri.Path) > 0 {
+		return nil, errMagnetHierPart
+	}
+
+	magnet := &Magnet{}
+	for _, param := range bytes.Split(uri.Query, []byte("&")) {
+		if len(param) == 0 {
+			continue
+		}
+		encodedName, encodedValue, found := bytes.Cut(param, []byte("="))
+		if !found {
+			return nil, errMagnetParamNotFound
+		}
+		name, err := decodePctEncoded(encodedName)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodePctEncoded(encodedValue)
+		if err != nil {
+			return nil, err
+		}
+
+		base := string(name)
+		if dot := strings.IndexByte(base, '.'); dot >= 0 && isDigits(base[dot+1:]) {
+			base = base[:dot]
+		}
+		switch {
+		case base == "xt":
+			topic, err := parseMagnetTopic(value)
+			if err != nil {
+				return nil, err
+			}
+			magnet.ExactTopics = append(magnet.ExactTopics, *topic)
+		case base == "tr":
+			magnet.Trackers = append(magnet.Trackers, string(value))
+		case base == "ws":
+			magnet.WebSeeds = append(magnet.WebSeeds, string(value))
+		case string(name) == "dn":
+			magnet.DisplayName = string(value)
+		case string(name) == "xl":
+			if !isDigits(string(value)) {
+				return nil, errExactLengthNotFound
+			}
+			magnet.ExactLength, err = strconv.ParseInt(string(value), 10, 64)
+			if err != nil {
+				return nil, errExactLengthNotFound
+			}
+		case string(name) == "so":
+			magnet.SelectOnly, err = parseMagnetRanges(string(value))
+			if err != nil {
+				return nil, err
+			}
+		default:
+			magnet.Params = append(magnet.Params, MagnetParam{Name: string(name), Value: string(value)})
+		}
+	}
+	if len(magnet.ExactTopics) == 0 {
+		return nil, errMagnetXtNotFound
+	}
+	return magnet, nil
+}
+
+// InfoHashes returns the info hashes of the btih exact topics.
+func (magnet *Magnet) InfoHashes() [][]byte {
+	hashes := [][]byte{}
+	for _, topic := range magnet.ExactTopics {
+		if strings.EqualFold(topic.Nid, "btih") {
+			hashes = append(hashes, topic.Hash)
+		}
+	}
+	return hashes
+}
+
+// String encodes the magnet link. The parameters are in the order of xt, dn,
+// xl, tr, ws, so and the others.
+func (magnet *Magnet) String() string {
+	params := []string{}
+	for _, topic := range magnet.ExactTopics {
+		params = append(params, "xt="+topic.String())
+	}
+	if magnet.DisplayName != "" {
+		params = append(params, "dn="+encodeMagnetValue(magnet.DisplayName))
+	}
+	if magnet.ExactLength > 0 {
+		params = append(params, "xl="+strconv.FormatInt(magnet.ExactLength, 10))
+	}
+	for _, tracker := range magnet.Trackers {
+		params = append(params, "tr="+encodeMagnetValue(tracker))
+	}
+	for _, webSeed := range magnet.WebSeeds {
+		params = append(params, "ws="+encodeMagnetValue(webSeed))
+	}
+	if len(magnet.SelectOnly) > 0 {
+		ranges := []string{}
+		for _, r := range magnet.SelectOnly {
+			str := strconv.Itoa(r.First)
+			if r.Last != r.First {
+				str += "-" + strconv.Itoa(r.Last)
+			}
+			ranges = append(ranges, str)
+		}
+		params = append(params, "so="+strings.Join(ranges, ","))
+	}
+	for _, param := range magnet.Params {
+		params = append(params, encodeMagnetValue(param.Name)+"="+encodeMagnetValue(param.Value))
+	}
+	return "magnet:?" + strings.Join(params, "&")
+}
+
+func parseMagnetTopic(value []byte) (*MagnetTopic, error) {
+	urn, err := ParseUrn(value)
+	if err != nil {
+		return nil, err
+	}
+	topic := &MagnetTopic{Urn: *urn}
+	switch strings.ToLower(urn.Nid) {
+	case "btih":
+		switch len(urn.Nss) {
+		case 40:
+			topic.Hash, err = hex.DecodeString(urn.Nss)
+		case 32:
+			topic.Hash, err = base32.StdEncoding.DecodeString(strings.ToUpper(urn.Nss))
+		default:
+			err = errBtihNotFound
+		}
+		if err != nil {
+			return nil, errBtihNotFound
+		}
+	case "btmh":
+		// BEP 52 - The multihash is 0x12 (sha2-256), 0x20 (32 bytes) and
+		// the digest.
+		topic.Hash, err = hex.DecodeString(urn.Nss)
+		if err != nil || len(topic.Hash) != 34 || topic.Hash[0] != 0x12 || topic.Hash[1] != 0x20 {
+			return nil, errBtmhNotFound
+		}
+	}
+	return topic, nil
+}
+
+// BEP 53 - Magnet URI extension - Select specific file indices for download
+//
+//	so=0,2,4,6-8
+//
+// The indices are 0-based, and the ranges are inclusive.
+
+func parseMagnetRanges(value string) ([]MagnetRange, error) {
+	ranges := []MagnetRange{}
+	for _, item := range strings.Split(value, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+		if !isRange {
+			last = first
+		}
+		if !isDigits(first) || !isDigits(last) {
+			return nil, errSelectOnlyNotFound
+		}
+		r := MagnetRange{}
+		var err error
+		if r.First, err = strconv.Atoi(first); err != nil {
+			return nil, errSelectOnlyNotFound
+		}
+		if r.Last, err = strconv.Atoi(last); err != nil || r.Last < r.First {
+			return nil, errSelectOnlyNotFound
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+func encodeMagnetValue(value string) string {
+	return string(encodePctEncoded([]byte(value), func(c byte) bool {
+		return hasClass(c, classQuery) && c != '&' && c != '=' && c != '+'
+	}))
+}
@@ -0,0 +1,112 @@
+package urip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestParseMagnet(t *testing.T) {
+	type TestCase struct {
+		testName       string
+		data           []byte
+		expectedHashes []string
+		expectedMagnet string
+		expectedString string
+		expectedErr    error
+	}
+
+	btih := "c12fe1c06bba254a9dc9f519b335aa7c1367a88a"
+	btmh := "1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e"
+	tests := []TestCase{
+		{
+			testName:       "data: []byte(\"magnet:?xt=urn:btih:" + btih + "&dn=Big%20Buck%20Bunny&tr=udp%3A%2F%2Fexplodie.org%3A6969&xl=276445467\")",
+			data:           []byte("magnet:?xt=urn:btih:" + btih + "&dn=Big%20Buck%20Bunny&tr=udp%3A%2F%2Fexplodie.org%3A6969&xl=276445467"),
+			expectedHashes: []string{btih},
+			expectedMagnet: "{ExactTopics:[{Urn:{Nid:btih Nss:" + btih + " RComponent: QComponent: FComponent:} Hash:[193 47 225 192 107 186 37 74 157 201 245 25 179 53 170 124 19 103 168 138]}] " +
+				"DisplayName:Big Buck Bunny ExactLength:276445467 Trackers:[udp://explodie.org:6969] WebSeeds:[] SelectOnly:[] Params:[]}",
+			expectedString: "magnet:?xt=urn:btih:" + btih + "&dn=Big%20Buck%20Bunny&xl=276445467&tr=udp://explodie.org:6969",
+		},
+		{
+			testName:       "data: []byte(\"magnet:?xt=urn:btih:YEX6DQDLXISUVHOJ6UM3GNNKPQJWPKEK&xt=urn:btmh:" + btmh + "&ws=https%3A%2F%2Fexample.com%2Ff%3Fa%3D1%26b%3D2&so=0,2,4-6&x.pe=10.0.0.1:6881\")",
+			data:           []byte("magnet:?xt=urn:btih:YEX6DQDLXISUVHOJ6UM3GNNKPQJWPKEK&xt=urn:btmh:" + btmh + "&ws=https%3A%2F%2Fexample.com%2Ff%3Fa%3D1%26b%3D2&so=0,2,4-6&x.pe=10.0.0.1:6881"),
+			expectedHashes: []string{btih},
+			expectedString: "magnet:?xt=urn:btih:YEX6DQDLXISUVHOJ6UM3GNNKPQJWPKEK&xt=urn:btmh:" + btmh + "&ws=https://example.com/f?a%3D1%26b%3D2&so=0,2,4-6&x.pe=10.0.0.1:6881",
+		},
+		{
+			testName:       "data: []byte(\"magnet:?xt.1=urn:sha1:YNCKHTQCWBTRNJIV4WNAE52SJUQCZO5C&xt.2=urn:btih:" + btih + "&tr.1=http%3A%2F%2Fa&tr.2=http%3A%2F%2Fb\")",
+			data:           []byte("magnet:?xt.1=urn:sha1:YNCKHTQCWBTRNJIV4WNAE52SJUQCZO5C&xt.2=urn:btih:" + btih + "&tr.1=http%3A%2F%2Fa&tr.2=http%3A%2F%2Fb"),
+			expectedHashes: []string{btih},
+			expectedString: "magnet:?xt=urn:sha1:YNCKHTQCWBTRNJIV4WNAE52SJUQCZO5C&xt=urn:btih:" + btih + "&tr=http://a&tr=http://b",
+		},
+		{
+			testName:    "data: []byte(\"http://example.com/?xt=urn:btih:" + btih + "\")",
+			data:        []byte("http://example.com/?xt=urn:btih:" + btih),
+			expectedErr: errMagnetSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:x?xt=urn:btih:" + btih + "\")",
+			data:        []byte("magnet:x?xt=urn:btih:" + btih),
+			expectedErr: errMagnetHierPart,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?dn=a\")",
+			data:        []byte("magnet:?dn=a"),
+			expectedErr: errMagnetXtNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt\")",
+			data:        []byte("magnet:?xt"),
+			expectedErr: errMagnetParamNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt=urn:btih:c12f\")",
+			data:        []byte("magnet:?xt=urn:btih:c12f"),
+			expectedErr: errBtihNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt=urn:btih:" + btih[:39] + "g\")",
+			data:        []byte("magnet:?xt=urn:btih:" + btih[:39] + "g"),
+			expectedErr: errBtihNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt=urn:btmh:1114" + btih + "\")",
+			data:        []byte("magnet:?xt=urn:btmh:1114" + btih),
+			expectedErr: errBtmhNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt=urn:btih:" + btih + "&xl=-1\")",
+			data:        []byte("magnet:?xt=urn:btih:" + btih + "&xl=-1"),
+			expectedErr: errExactLengthNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt=urn:btih:" + btih + "&so=3-1\")",
+			data:        []byte("magnet:?xt=urn:btih:" + btih + "&so=3-1"),
+			expectedErr: errSelectOnlyNotFound,
+		},
+		{
+			testName:    "data: []byte(\"magnet:?xt=urn:btih:" + btih + "&so=1,,2\")",
+			data:        []byte("magnet:?xt=urn:btih:" + btih + "&so=1,,2"),
+			expectedErr: errSelectOnlyNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			magnet, err := ParseMagnet(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			hashes := []string{}
+			for _, hash := range magnet.InfoHashes() {
+				hashes = append(hashes, hex.EncodeToString(hash))
+			}
+			equals(testCase.testName+"(InfoHashes)", t, fmt.Sprint(testCase.expectedHashes), fmt.Sprint(hashes))
+			if testCase.expectedMagnet != "" {
+				equals(testCase.testName+"(Magnet)", t, testCase.expectedMagnet, fmt.Sprintf("%+v", *magnet))
+			}
+			equals(testCase.testName+"(String)", t, testCase.expectedString, magnet.String())
+		})
+	}
+}
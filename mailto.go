@@ -0,0 +1,296 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	errMailtoSchemeNotFound = errors.New("mailto scheme not found.")
+	errMailtoAuthority      = errors.New("mailto URI cannot have authority.")
+	errMailtoFragment       = errors.New("mailto URI cannot have fragment.")
+	errHfieldNotFound       = errors.New("hfield not found.")
+	errAddrSpecNotFound     = errors.New("addr-spec not found.")
+)
+
+// Mailto is a mailto URI.
+type Mailto struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	Subject string
+	Body    string
+	Headers []MailtoHeader // hfields other than to, cc, bcc, subject and body
+}
+
+type MailtoHeader struct {
+	Name  string
+	Value string
+}
+
+// ParseMailto parses a mailto URI, and decodes the addresses and the header
+// fields.
+//
+// RFC6068 - 2. Syntax of a 'mailto' URI
+//
+//	mailtoURI    = "mailto:" [ to ] [ hfields ]
+//	to           = addr-spec *("," addr-spec )
+//	hfields      = "?" hfield *( "&" hfield )
+//	hfield       = hfname "=" hfvalue
+//	hfname       = *qchar
+//	hfvalue      = *qchar
+//
+// The addresses in "to", "cc" and "bcc" are validated as addr-spec.
+// The addresses of the "to" hfields are added to the addresses of "to".
+func ParseMailto(data []byte) (*Mailto, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("mailto")) {
+		return nil, errMailtoSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 {
+		return nil, errMailtoAuthority
+	}
+	if len(uri.Sharp) > 0 {
+		return nil, errMailtoFragment
+	}
+
+	mailto := &Mailto{}
+	mailto.To, err = decodeAddrSpecs(uri.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(uri.Question) == 0 {
+		return mailto, nil
+	}
+	for _, hfield := range bytes.Split(uri.Query, []byte("&")) {
+		name, value, found := bytes.Cut(hfield, []byte("="))
+		if !found {
+			return nil, errHfieldNotFound
+		}
+		decodedName, err := decodePctEncoded(name)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(string(decodedName)) {
+		case "to":
+			addrs, err := decodeAddrSpecs(value)
+			if err != nil {
+				return nil, err
+			}
+			mailto.To = append(mailto.To, addrs...)
+			continue
+		case "cc":
+			addrs, err := decodeAddrSpecs(value)
+			if err != nil {
+				return nil, err
+			}
+			mailto.Cc = append(mailto.Cc, addrs...)
+			continue
+		case "bcc":
+			addrs, err := decodeAddrSpecs(value)
+			if err != nil {
+				return nil, err
+			}
+			mailto.Bcc = append(mailto.Bcc, addrs...)
+			continue
+		}
+		decodedValue, err := decodePctEncoded(value)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(string(decodedName)) {
+		case "subject":
+			mailto.Subject = string(decodedValue)
+		case "body":
+			mailto.Body = string(decodedValue)
+		default:
+			mailto.Headers = append(mailto.Headers, MailtoHeader{
+				Name:  string(decodedName),
+				Value: string(decodedValue),
+			})
+		}
+	}
+	return mailto, nil
+}
+
+// String encodes the mailto URI. The addresses and the header fields are
+// encoded as UTF-8 and pct-encoded, so non-ASCII subjects are kept.
+func (mailto *Mailto) String() string {
+	str := "mailto:"
+	str += encodeAddrSpecs(mailto.To)
+
+	hfields := []string{}
+	if len(mailto.Cc) > 0 {
+		hfields = append(hfields, "cc="+encodeAddrSpecs(mailto.Cc))
+	}
+	if len(mailto.Bcc) > 0 {
+		hfields = append(hfields, "bcc="+encodeAddrSpecs(mailto.Bcc))
+	}
+	if mailto.Subject != "" {
+		hfields = append(hfields, "subject="+string(encodePctEncoded([]byte(mailto.Subject), isQchar)))
+	}
+	if mailto.Body != "" {
+		hfields = append(hfields, "body="+string(encodePctEncoded([]byte(mailto.Body), isQchar)))
+	}
+	for _, header := range mailto.Headers {
+		hfields = append(hfields,
+			string(encodePctEncoded([]byte(header.Name), isQchar))+"="+
+				string(encodePctEncoded([]byte(header.Value), isQchar)))
+	}
+	if len(hfields) > 0 {
+		str += "?" + strings.Join(hfields, "&")
+	}
+	return str
+}
+
+// RFC6068 - 2. Syntax of a 'mailto' URI
+//
+//  qchar        = unreserved / pct-encoded / some-delims
+//  some-delims  = "!" / "$" / "'" / "(" / ")" / "*"
+//               / "+" / "," / ";" / ":" / "@"
+//
+
+func isQchar(c byte) bool {
+	if isUnreserved(c) {
+		return true
+	}
+	return strings.IndexByte("!$'()*+,;:@", c) >= 0
+}
+
+// decodeAddrSpecs splits "to" with "," and decodes each addr-spec.
+// A "," in an addr-spec is pct-encoded, so it is not split.
+func decodeAddrSpecs(to []byte) ([]string, error) {
+	addrs := []string{}
+	if len(to) == 0 {
+		return addrs, nil
+	}
+	for _, encoded := range bytes.Split(to, []byte(",")) {
+		addr, err := decodePctEncoded(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if !isAddrSpec(addr) {
+			return nil, errAddrSpecNotFound
+		}
+		addrs = append(addrs, string(addr))
+	}
+	return addrs, nil
+}
+
+func encodeAddrSpecs(addrs []string) string {
+	encoded := []string{}
+	for _, addr := range addrs {
+		encoded = append(encoded, string(encodePctEncoded([]byte(addr), func(c byte) bool {
+			return c != ',' && isQchar(c)
+		})))
+	}
+	return strings.Join(encoded, ",")
+}
+
+// RFC5322 - 3.4.1. Addr-Spec Specification
+//
+//  addr-spec       =   local-part "@" domain
+//  local-part      =   dot-atom-text / quoted-string
+//  domain          =   dot-atom-text / "[" *dtext-no-obs "]"
+//  dtext-no-obs    =   %d33-90 /   ; Printable US-ASCII
+//                      %d94-126    ;  characters not including
+//                                  ;  "[", "]", or "\"
+//
+// RFC6068 - 2. Syntax of a 'mailto' URI
+// addr-spec of RFC6068 does not have the obsolete syntax and CFWS.
+// The octets of UTF-8 are allowed in atext and qtext as RFC6532 does.
+
+func isAddrSpec(addr []byte) bool {
+	at := bytes.LastIndexByte(addr, '@')
+	if at < 0 {
+		return false
+	}
+	localPart := addr[:at]
+	domain := addr[at+1:]
+	if !isDotAtomText(localPart) && !isQuotedString(localPart) {
+		return false
+	}
+	if isDotAtomText(domain) {
+		return true
+	}
+	if len(domain) < 2 || domain[0] != '[' || domain[len(domain)-1] != ']' {
+		return false
+	}
+	for _, c := range domain[1 : len(domain)-1] {
+		if c < 33 || c > 126 || c == '[' || c == ']' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+// RFC5322 - 3.2.3. Atom
+//
+//  atext           =   ALPHA / DIGIT /    ; Printable US-ASCII
+//                      "!" / "#" /        ;  characters not including
+//                      "$" / "%" /        ;  specials.  Used for atoms.
+//                      "&" / "'" /
+//                      "*" / "+" /
+//                      "-" / "/" /
+//                      "=" / "?" /
+//                      "^" / "_" /
+//                      "`" / "{" /
+//                      "|" / "}" /
+//                      "~"
+//  dot-atom-text   =   1*atext *("." 1*atext)
+//
+
+func isDotAtomText(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	for i, c := range data {
+		if c == '.' {
+			if i == 0 || i == len(data)-1 || data[i-1] == '.' {
+				return false
+			}
+			continue
+		}
+		if !isAlpha(c) && !isDigit(c) && c < 0x80 && strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RFC5322 - 3.2.4. Quoted Strings
+//
+//  qtext           =   %d33 /             ; Printable US-ASCII
+//                      %d35-91 /          ;  characters not including
+//                      %d93-126           ;  "\" or the quote character
+//  quoted-pair     =   ("\" (VCHAR / WSP))
+//  quoted-string   =   DQUOTE *([FWS] qcontent) [FWS] DQUOTE
+//
+
+func isQuotedString(data []byte) bool {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return false
+	}
+	content := data[1 : len(data)-1]
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\':
+			i++
+			if i == len(content) || (content[i] < 32 && content[i] != '\t') || content[i] == 127 {
+				return false
+			}
+		case c == '"':
+			return false
+		case c == ' ' || c == '\t' || c >= 0x80:
+			// FWS and UTF-8
+		case c < 33 || c == 127:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseMailto(t *testing.T) {
+	type TestCase struct {
+		testName        string
+		data            []byte
+		expectedTo      []string
+		expectedCc      []string
+		expectedBcc     []string
+		expectedSubject string
+		expectedBody    string
+		expectedHeaders []MailtoHeader
+		expectedErr     error
+	}
+
+	tests := []TestCase{
+		// RFC6068 - 6.1. Basic Examples
+		{
+			testName:        "data: []byte(\"mailto:chris@example.com\")",
+			data:            []byte("mailto:chris@example.com"),
+			expectedTo:      []string{"chris@example.com"},
+			expectedCc:      []string{},
+			expectedBcc:     []string{},
+			expectedHeaders: []MailtoHeader{},
+		},
+		{
+			testName:        "data: []byte(\"mailto:infobot@example.com?subject=current-issue\")",
+			data:            []byte("mailto:infobot@example.com?subject=current-issue"),
+			expectedTo:      []string{"infobot@example.com"},
+			expectedCc:      []string{},
+			expectedBcc:     []string{},
+			expectedSubject: "current-issue",
+			expectedHeaders: []MailtoHeader{},
+		},
+		{
+			testName:        "data: []byte(\"mailto:?to=joe@example.com&cc=bob@example.com&body=hello\")",
+			data:            []byte("mailto:?to=joe@example.com&cc=bob@example.com&body=hello"),
+			expectedTo:      []string{"joe@example.com"},
+			expectedCc:      []string{"bob@example.com"},
+			expectedBcc:     []string{},
+			expectedBody:    "hello",
+			expectedHeaders: []MailtoHeader{},
+		},
+		{
+			testName:        "data: []byte(\"mailto:joe@example.com,bob@example.com?bcc=a@example.com,b@example.com\")",
+			data:            []byte("mailto:joe@example.com,bob@example.com?bcc=a@example.com,b@example.com"),
+			expectedTo:      []string{"joe@example.com", "bob@example.com"},
+			expectedCc:      []string{},
+			expectedBcc:     []string{"a@example.com", "b@example.com"},
+			expectedHeaders: []MailtoHeader{},
+		},
+		{
+			testName:        "data: []byte(\"mailto:list@example.org?In-Reply-To=%3C3469A91.D10AF4C@example.com%3E\")",
+			data:            []byte("mailto:list@example.org?In-Reply-To=%3C3469A91.D10AF4C@example.com%3E"),
+			expectedTo:      []string{"list@example.org"},
+			expectedCc:      []string{},
+			expectedBcc:     []string{},
+			expectedHeaders: []MailtoHeader{{Name: "In-Reply-To", Value: "<3469A91.D10AF4C@example.com>"}},
+		},
+		// RFC6068 - 6.2. Examples of Complicated Email Addresses
+		{
+			testName:        "data: []byte(\"mailto:%22not%40me%22@example.org\")",
+			data:            []byte("mailto:%22not%40me%22@example.org"),
+			expectedTo:      []string{"\"not@me\"@example.org"},
+			expectedCc:      []string{},
+			expectedBcc:     []string{},
+			expectedHeaders: []MailtoHeader{},
+		},
+		// RFC6068 - 6.3. Examples Using UTF-8-Based Percent-Encoding
+		{
+			testName:        "data: []byte(\"mailto:user@example.org?subject=caf%C3%A9&body=a%0D%0Ab\")",
+			data:            []byte("mailto:user@example.org?subject=caf%C3%A9&body=a%0D%0Ab"),
+			expectedTo:      []string{"user@example.org"},
+			expectedCc:      []string{},
+			expectedBcc:     []string{},
+			expectedSubject: "café",
+			expectedBody:    "a\r\nb",
+			expectedHeaders: []MailtoHeader{},
+		},
+		{
+			testName:    "data: []byte(\"http://example.com\")",
+			data:        []byte("http://example.com"),
+			expectedErr: errMailtoSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"mailto://example.com\")",
+			data:        []byte("mailto://example.com"),
+			expectedErr: errMailtoAuthority,
+		},
+		{
+			testName:    "data: []byte(\"mailto:user@example.com#f\")",
+			data:        []byte("mailto:user@example.com#f"),
+			expectedErr: errMailtoFragment,
+		},
+		{
+			testName:    "data: []byte(\"mailto:user\")",
+			data:        []byte("mailto:user"),
+			expectedErr: errAddrSpecNotFound,
+		},
+		{
+			testName:    "data: []byte(\"mailto:a..b@example.com\")",
+			data:        []byte("mailto:a..b@example.com"),
+			expectedErr: errAddrSpecNotFound,
+		},
+		{
+			testName:    "data: []byte(\"mailto:user@example.com?subject\")",
+			data:        []byte("mailto:user@example.com?subject"),
+			expectedErr: errHfieldNotFound,
+		},
+		{
+			testName:    "data: []byte(\"mailto:user@example.com?subject=%zz\")",
+			data:        []byte("mailto:user@example.com?subject=%zz"),
+			expectedErr: errTrailingData,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			mailto, err := ParseMailto(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			sliceHasSameElem(testCase.testName+"(To)", t, testCase.expectedTo, mailto.To)
+			sliceHasSameElem(testCase.testName+"(Cc)", t, testCase.expectedCc, mailto.Cc)
+			sliceHasSameElem(testCase.testName+"(Bcc)", t, testCase.expectedBcc, mailto.Bcc)
+			equals(testCase.testName+"(Subject)", t, testCase.expectedSubject, mailto.Subject)
+			equals(testCase.testName+"(Body)", t, testCase.expectedBody, mailto.Body)
+			sliceHasSameElem(testCase.testName+"(Headers)", t, testCase.expectedHeaders, mailto.Headers)
+		})
+	}
+}
+
+func TestMailtoString(t *testing.T) {
+	mailto := &Mailto{
+		To:      []string{"joe@example.com", "\"a,b\"@example.com"},
+		Cc:      []string{"bob@example.com"},
+		Subject: "日本語 & more",
+		Body:    "line1\r\nline2",
+		Headers: []MailtoHeader{{Name: "X-Id", Value: "a=b"}},
+	}
+	expected := "mailto:joe@example.com,%22a%2Cb%22@example.com" +
+		"?cc=bob@example.com" +
+		"&subject=%E6%97%A5%E6%9C%AC%E8%AA%9E%20%26%20more" +
+		"&body=line1%0D%0Aline2" +
+		"&X-Id=a%3Db"
+	equals("String", t, expected, mailto.String())
+
+	parsed, err := ParseMailto([]byte(mailto.String()))
+	if err != nil {
+		t.Errorf("Failed to parse mailto: %v", err.Error())
+		return
+	}
+	sliceHasSameElem("To", t, mailto.To, parsed.To)
+	sliceHasSameElem("Cc", t, mailto.Cc, parsed.Cc)
+	equals("Subject", t, mailto.Subject, parsed.Subject)
+	equals("Body", t, mailto.Body, parsed.Body)
+	sliceHasSameElem("Headers", t, mailto.Headers, parsed.Headers)
+}
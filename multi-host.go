@@ -0,0 +1,196 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	errUserInfoNotFound     = errors.New("userinfo not found.")
+	errHostListHostNotFound = errors.New("host of host list not found.")
+	errHostListPortNotFound = errors.New("port of host list not found.")
+)
+
+// HostPort is a host and a port of an authority. Port is "" if it is omitted.
+type HostPort struct {
+	Host string
+	Port string
+}
+
+// MultiHostUri is a Uri whose authority has a list of hosts instead of a
+// single host and port. Like Uri, all the components are not decoded.
+type MultiHostUri struct {
+	Scheme      []byte
+	DoubleSlash []byte     // part of hier-part
+	UserInfo    []byte     // part of hier-part
+	AtSign      []byte     // part of hier-part
+	Hosts       []HostPort // part of hier-part, empty if the host is omitted
+	Path        []byte     // part of hier-part
+	Question    []byte
+	Query       []byte
+	Sharp       []byte
+	Fragment    []byte
+}
+
+// ParseMultiHost parses data in the same way as Parse, except that the
+// authority may have a list of hosts separated with ",", as used by the
+// connection URIs of mongodb, Kafka, Cassandra and JDBC.
+//
+//	authority = [ userinfo "@" ] host-list
+//	host-list = [ host [ ":" port ] *( "," host [ ":" port ] ) ]
+//
+// If data has no authority, Hosts is nil.
+//
+// NOTE
+// Unlike Parse, the port must not be empty when ":" is present, since
+// HostPort cannot tell an empty port from an omitted one.
+// The host list is cut out from data, and the rest is parsed by Parse as a
+// URI with an empty host.
+func ParseMultiHost(data []byte) (*MultiHostUri, error) {
+	colon := bytes.IndexByte(data, ':')
+	if colon < 0 || !bytes.HasPrefix(data[colon+1:], []byte("//")) {
+		uri, err := parseEntire(data)
+		if err != nil {
+			return nil, err
+		}
+		return newMultiHostUri(uri, nil), nil
+	}
+
+	authorityStart := colon + len("://")
+	authorityEnd := len(data)
+	if end := bytes.IndexAny(data[authorityStart:], "/?#"); end >= 0 {
+		authorityEnd = authorityStart + end
+	}
+	hostListStart := authorityStart
+	if at := bytes.LastIndexByte(data[authorityStart:authorityEnd], '@'); at >= 0 {
+		hostListStart = authorityStart + at + 1
+		if err := checkUserInfo(data[authorityStart : authorityStart+at]); err != nil {
+			return nil, err
+		}
+	}
+
+	hostless := make([]byte, 0, len(data)-(authorityEnd-hostListStart))
+	hostless = append(hostless, data[:hostListStart]...)
+	hostless = append(hostless, data[authorityEnd:]...)
+	uri, err := parseEntire(hostless)
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := splitHostList(string(data[hostListStart:authorityEnd]))
+	if err != nil {
+		return nil, err
+	}
+	return newMultiHostUri(uri, hosts), nil
+}
+
+// checkUserInfo reports why userinfo is not valid, so that the cause is not
+// hidden by errTrailingData of parseEntire.
+//
+// RFC3986 - 3.2.1. User Information
+//
+//	userinfo    = *( unreserved / pct-encoded / sub-delims / ":" )
+func checkUserInfo(userInfo []byte) error {
+	end := scanClass(userInfo, 0, classUserInfo)
+	if end == len(userInfo) {
+		return nil
+	}
+	if userInfo[end] == '%' {
+		return errInvalidPctEncoded
+	}
+	return errUserInfoNotFound
+}
+
+func newMultiHostUri(uri *Uri, hosts []HostPort) *MultiHostUri {
+	return &MultiHostUri{
+		Scheme:      uri.Scheme,
+		DoubleSlash: uri.DoubleSlash,
+		UserInfo:    uri.UserInfo,
+		AtSign:      uri.AtSign,
+		Hosts:       hosts,
+		Path:        uri.Path,
+		Question:    uri.Question,
+		Query:       uri.Query,
+		Sharp:       uri.Sharp,
+		Fragment:    uri.Fragment,
+	}
+}
+
+func (uri *MultiHostUri) String() string {
+	str := string(uri.Scheme)
+	str += ":"
+	if len(uri.DoubleSlash) > 0 {
+		str += string(uri.DoubleSlash)
+		str += uri.GetAuthority()
+	}
+	str += string(uri.Path)
+	str += string(uri.Question)
+	str += string(uri.Query)
+	str += string(uri.Sharp)
+	str += string(uri.Fragment)
+	return str
+}
+
+func (uri *MultiHostUri) GetAuthority() string {
+	//	authority = [ userinfo "@" ] host-list
+	//	host-list = [ host [ ":" port ] *( "," host [ ":" port ] ) ]
+	//
+	var str string
+	str += string(uri.UserInfo)
+	str += string(uri.AtSign)
+	hosts := []string{}
+	for _, host := range uri.Hosts {
+		hostport := host.Host
+		if host.Port != "" {
+			hostport += ":" + host.Port
+		}
+		hosts = append(hosts, hostport)
+	}
+	str += strings.Join(hosts, ",")
+	return str
+}
+
+// splitHostList splits the list of host [ ":" port ] separated with ",".
+// The hosts are not decoded, and the IP-literals are kept with "[" and "]".
+// An empty list has no hosts.
+func splitHostList(hostList string) ([]HostPort, error) {
+	hosts := []HostPort{}
+	if hostList == "" {
+		return hosts, nil
+	}
+	for _, hostport := range strings.Split(hostList, ",") {
+		host, port := hostport, ""
+		if strings.HasPrefix(hostport, "[") {
+			end := strings.IndexByte(hostport, ']')
+			if end < 0 {
+				return nil, errHostListHostNotFound
+			}
+			literal := []byte(hostport[1:end])
+			if !isIpV6Address(literal) && !isIpVFuture(literal) {
+				return nil, errHostListHostNotFound
+			}
+			host, port = hostport[:end+1], hostport[end+1:]
+			if port != "" && port[0] != ':' {
+				return nil, errHostListHostNotFound
+			}
+			if port == ":" {
+				return nil, errHostListPortNotFound
+			}
+			port = strings.TrimPrefix(port, ":")
+		} else {
+			var found bool
+			host, port, found = strings.Cut(hostport, ":")
+			if found && port == "" {
+				return nil, errHostListPortNotFound
+			}
+			if host == "" || scanClass([]byte(host), 0, classRegName) != len(host) {
+				return nil, errHostListHostNotFound
+			}
+		}
+		if port != "" && !isDigits(port) {
+			return nil, errHostListPortNotFound
+		}
+		hosts = append(hosts, HostPort{Host: host, Port: port})
+	}
+	return hosts, nil
+}
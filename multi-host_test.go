@@ -0,0 +1,119 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseMultiHost(t *testing.T) {
+	type TestCase struct {
+		testName      string
+		data          []byte
+		expectedHosts []HostPort
+		expectedPath  []byte
+		expectedErr   error
+	}
+
+	tests := []TestCase{
+		{
+			testName:      "data: []byte(\"kafka://broker1:9092,broker2:9092,broker3:9093\")",
+			data:          []byte("kafka://broker1:9092,broker2:9092,broker3:9093"),
+			expectedHosts: []HostPort{{Host: "broker1", Port: "9092"}, {Host: "broker2", Port: "9092"}, {Host: "broker3", Port: "9093"}},
+		},
+		{
+			testName:      "data: []byte(\"mongodb://user:pw@db1,db2:27018/admin?replicaSet=rs0#x\")",
+			data:          []byte("mongodb://user:pw@db1,db2:27018/admin?replicaSet=rs0#x"),
+			expectedHosts: []HostPort{{Host: "db1"}, {Host: "db2", Port: "27018"}},
+			expectedPath:  []byte("/admin"),
+		},
+		{
+			testName:      "data: []byte(\"cassandra://[::1]:9042,192.0.2.1,[v1.fe]/ks\")",
+			data:          []byte("cassandra://[::1]:9042,192.0.2.1,[v1.fe]/ks"),
+			expectedHosts: []HostPort{{Host: "[::1]", Port: "9042"}, {Host: "192.0.2.1"}, {Host: "[v1.fe]"}},
+			expectedPath:  []byte("/ks"),
+		},
+		{
+			testName:      "data: []byte(\"http://ex%61mple.com/a\")",
+			data:          []byte("http://ex%61mple.com/a"),
+			expectedHosts: []HostPort{{Host: "ex%61mple.com"}},
+			expectedPath:  []byte("/a"),
+		},
+		{
+			testName:      "data: []byte(\"file:///etc/hosts\")",
+			data:          []byte("file:///etc/hosts"),
+			expectedHosts: []HostPort{},
+			expectedPath:  []byte("/etc/hosts"),
+		},
+		{
+			testName:     "data: []byte(\"mailto:a@example.com,b@example.com\")",
+			data:         []byte("mailto:a@example.com,b@example.com"),
+			expectedPath: []byte("a@example.com,b@example.com"),
+		},
+		{
+			testName:    "data: []byte(\"kafka://broker1,,broker2\")",
+			data:        []byte("kafka://broker1,,broker2"),
+			expectedErr: errHostListHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"kafka://broker1,\")",
+			data:        []byte("kafka://broker1,"),
+			expectedErr: errHostListHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"kafka://[::1]x,broker2\")",
+			data:        []byte("kafka://[::1]x,broker2"),
+			expectedErr: errHostListHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"kafka://broker1:,broker2\")",
+			data:        []byte("kafka://broker1:,broker2"),
+			expectedErr: errHostListPortNotFound,
+		},
+		{
+			testName:    "data: []byte(\"kafka://broker1:90a2\")",
+			data:        []byte("kafka://broker1:90a2"),
+			expectedErr: errHostListPortNotFound,
+		},
+		{
+			testName:    "data: []byte(\"kafka://u%zz@broker1,broker2\")",
+			data:        []byte("kafka://u%zz@broker1,broker2"),
+			expectedErr: errInvalidPctEncoded,
+		},
+		{
+			testName:    "data: []byte(\"kafka://u[@broker1,broker2\")",
+			data:        []byte("kafka://u[@broker1,broker2"),
+			expectedErr: errUserInfoNotFound,
+		},
+		{
+			testName:    "data: []byte(\"kafka://broker1/a b\")",
+			data:        []byte("kafka://broker1/a b"),
+			expectedErr: errTrailingData,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			uri, err := ParseMultiHost(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(Hosts)", t, fmt.Sprintf("%+v", testCase.expectedHosts), fmt.Sprintf("%+v", uri.Hosts))
+			byteEquals(testCase.testName+"(Path)", t, testCase.expectedPath, uri.Path)
+			equals(testCase.testName+"(String)", t, string(testCase.data), uri.String())
+		})
+	}
+}
+
+func TestMultiHostUriString(t *testing.T) {
+	uri := MultiHostUri{
+		Scheme:      []byte("jdbc-failover"),
+		DoubleSlash: []byte("//"),
+		UserInfo:    []byte("app"),
+		AtSign:      []byte("@"),
+		Hosts:       []HostPort{{Host: "primary", Port: "3306"}, {Host: "[2001:db8::1]"}},
+		Path:        []byte("/db"),
+	}
+	equals("String", t, "jdbc-failover://app@primary:3306,[2001:db8::1]/db", uri.String())
+	equals("GetAuthority", t, "app@primary:3306,[2001:db8::1]", uri.GetAuthority())
+}
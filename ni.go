@@ -0,0 +1,181 @@
+package urip
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var (
+	errNiSchemeNotFound    = errors.New("ni scheme not found.")
+	errNiAuthorityNotFound = errors.New("authority of ni URI not found.")
+	errNiFragment          = errors.New("ni URI cannot have fragment.")
+	errAlgValNotFound      = errors.New("alg-val not found.")
+	errNiDigestLength      = errors.New("length of digest of ni URI mismatch.")
+	errNiUnknownAlgorithm  = errors.New("unknown hash algorithm of ni URI.")
+	errNiDigestMismatch    = errors.New("digest of ni URI mismatch.")
+)
+
+// niAlgorithms is the Named Information Hash Algorithm Registry of RFC6920 -
+// 9.4. The truncated variants are the leading bytes of the SHA-256 digest.
+var niAlgorithms = map[string]int{
+	"sha-256":     32,
+	"sha-256-128": 16,
+	"sha-256-120": 15,
+	"sha-256-96":  12,
+	"sha-256-64":  8,
+	"sha-256-32":  4,
+}
+
+// Ni is a named information URI.
+type Ni struct {
+	Authority string // "" if it is omitted
+	Algorithm string
+	Digest    []byte // decoded from base64url
+	Params    []NiParam
+}
+
+type NiParam struct {
+	Name  string
+	Value string
+}
+
+// ParseNi parses a named information URI.
+//
+// RFC6920 - 3. Named Information (ni) URI Format
+//
+//	NI-URI         = ni-scheme ":" ni-hier-part [ "?" query ]
+//	ni-scheme      = "ni"
+//	ni-hier-part   = "//" [ authority ] "/" alg-val
+//	alg-val        = alg ";" val
+//	alg            = 1*unreserved
+//	val            = 1*unreserved
+//	query          = ni-query-param *( "&" ni-query-param )
+//	ni-query-param = ( ct-param / other-param )
+//	ct-param       = "ct=" ( type "/" subtype )
+//
+// val is base64url encoded without padding. The length of the digest is
+// checked if the algorithm is in the registry.
+func ParseNi(data []byte) (*Ni, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("ni")) {
+		return nil, errNiSchemeNotFound
+	}
+	if len(uri.DoubleSlash) == 0 {
+		return nil, errNiAuthorityNotFound
+	}
+	if len(uri.Sharp) > 0 {
+		return nil, errNiFragment
+	}
+	ni := &Ni{Authority: uri.GetAuthority()}
+
+	if len(uri.Path) == 0 {
+		return nil, errAlgValNotFound
+	}
+	alg, val, found := strings.Cut(string(uri.Path[1:]), ";")
+	if !found || !isNiUnreserved(alg) || !isNiUnreserved(val) {
+		return nil, errAlgValNotFound
+	}
+	ni.Algorithm = alg
+	ni.Digest, err = base64.RawURLEncoding.DecodeString(val)
+	if err != nil {
+		return nil, errAlgValNotFound
+	}
+	if length, found := niAlgorithms[alg]; found && len(ni.Digest) != length {
+		return nil, errNiDigestLength
+	}
+
+	if len(uri.Query) > 0 {
+		for _, param := range strings.Split(string(uri.Query), "&") {
+			name, value, _ := strings.Cut(param, "=")
+			decodedName, err := decodePctEncoded([]byte(name))
+			if err != nil {
+				return nil, err
+			}
+			decodedValue, err := decodePctEncoded([]byte(value))
+			if err != nil {
+				return nil, err
+			}
+			ni.Params = append(ni.Params, NiParam{Name: string(decodedName), Value: string(decodedValue)})
+		}
+	}
+	return ni, nil
+}
+
+// NewNi returns a named information URI of the content, hashed with the
+// algorithm in the registry.
+func NewNi(authority string, algorithm string, content []byte) (*Ni, error) {
+	length, found := niAlgorithms[algorithm]
+	if !found {
+		return nil, errNiUnknownAlgorithm
+	}
+	digest := sha256.Sum256(content)
+	return &Ni{Authority: authority, Algorithm: algorithm, Digest: digest[:length]}, nil
+}
+
+// ContentType returns the value of the ct parameter, or "" if it is omitted.
+func (ni *Ni) ContentType() string {
+	for _, param := range ni.Params {
+		if param.Name == "ct" {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// Verify checks the content against the digest. It fails if the algorithm
+// is not in the registry.
+func (ni *Ni) Verify(content []byte) error {
+	length, found := niAlgorithms[ni.Algorithm]
+	if !found {
+		return errNiUnknownAlgorithm
+	}
+	digest := sha256.Sum256(content)
+	if subtle.ConstantTimeCompare(digest[:length], ni.Digest) != 1 {
+		return errNiDigestMismatch
+	}
+	return nil
+}
+
+// Equivalent reports whether the ni URIs name the same object. As RFC6920 -
+// 2. Hashes Are What Count says, the authority and the parameters are
+// ignored, and the algorithm and the digest are compared.
+func (ni *Ni) Equivalent(other *Ni) bool {
+	return ni.Algorithm == other.Algorithm && bytes.Equal(ni.Digest, other.Digest)
+}
+
+func (ni *Ni) String() string {
+	str := "ni://" + ni.Authority + "/" + ni.Algorithm + ";" + base64.RawURLEncoding.EncodeToString(ni.Digest)
+	params := []string{}
+	for _, param := range ni.Params {
+		params = append(params,
+			string(encodePctEncoded([]byte(param.Name), isNiParamChar))+"="+
+				string(encodePctEncoded([]byte(param.Value), isNiParamChar)))
+	}
+	if len(params) > 0 {
+		str += "?" + strings.Join(params, "&")
+	}
+	return str
+}
+
+func isNiUnreserved(str string) bool {
+	if str == "" {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if !isUnreserved(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNiParamChar(c byte) bool {
+	return hasClass(c, classQuery) && c != '&' && c != '='
+}
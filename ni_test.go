@@ -0,0 +1,135 @@
+package urip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestParseNi(t *testing.T) {
+	type TestCase struct {
+		testName       string
+		data           []byte
+		expectedNi     string
+		expectedString string
+		expectedErr    error
+	}
+
+	tests := []TestCase{
+		// RFC6920 - 8.1. Hello World!
+		{
+			testName:       "data: []byte(\"ni:///sha-256;f4OxZX_x_FO5LcGBSKHWXfwtSx-j1ncoSt3SABJtkGk\")",
+			data:           []byte("ni:///sha-256;f4OxZX_x_FO5LcGBSKHWXfwtSx-j1ncoSt3SABJtkGk"),
+			expectedNi:     "{Authority: Algorithm:sha-256 Digest:7f83b1657ff1fc53b92dc18148a1d65dfc2d4b1fa3d677284addd200126d9069 Params:[]}",
+			expectedString: "ni:///sha-256;f4OxZX_x_FO5LcGBSKHWXfwtSx-j1ncoSt3SABJtkGk",
+		},
+		{
+			testName:       "data: []byte(\"ni://example.com/sha-256-128;f4OxZX_x_FO5LcGBSKHWXQ?ct=text/plain\")",
+			data:           []byte("ni://example.com/sha-256-128;f4OxZX_x_FO5LcGBSKHWXQ?ct=text/plain"),
+			expectedNi:     "{Authority:example.com Algorithm:sha-256-128 Digest:7f83b1657ff1fc53b92dc18148a1d65d Params:[{Name:ct Value:text/plain}]}",
+			expectedString: "ni://example.com/sha-256-128;f4OxZX_x_FO5LcGBSKHWXQ?ct=text/plain",
+		},
+		{
+			testName:       "data: []byte(\"NI://user@example.com:8080/x-unknown;AQID\")",
+			data:           []byte("NI://user@example.com:8080/x-unknown;AQID"),
+			expectedNi:     "{Authority:user@example.com:8080 Algorithm:x-unknown Digest:010203 Params:[]}",
+			expectedString: "ni://user@example.com:8080/x-unknown;AQID",
+		},
+		{
+			testName:    "data: []byte(\"nih:sha-256-32;7f83b1;a\")",
+			data:        []byte("nih:sha-256-32;7f83b1;a"),
+			expectedErr: errNiSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ni:sha-256;f4OxZX_x_FO5LcGBSKHWXQ\")",
+			data:        []byte("ni:sha-256;f4OxZX_x_FO5LcGBSKHWXQ"),
+			expectedErr: errNiAuthorityNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ni:///sha-256;f4OxZQ#f\")",
+			data:        []byte("ni:///sha-256;f4OxZQ#f"),
+			expectedErr: errNiFragment,
+		},
+		{
+			testName:    "data: []byte(\"ni://example.com\")",
+			data:        []byte("ni://example.com"),
+			expectedErr: errAlgValNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ni:///sha-256\")",
+			data:        []byte("ni:///sha-256"),
+			expectedErr: errAlgValNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ni:///sha-256;f4OxZQ/x\")",
+			data:        []byte("ni:///sha-256;f4OxZQ/x"),
+			expectedErr: errAlgValNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ni:///sha-256;f4OxZQ==\")",
+			data:        []byte("ni:///sha-256;f4OxZQ=="),
+			expectedErr: errAlgValNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ni:///sha-256;f4OxZQ\")",
+			data:        []byte("ni:///sha-256;f4OxZQ"),
+			expectedErr: errNiDigestLength,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			ni, err := ParseNi(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName, t, testCase.expectedNi, fmt.Sprintf("%+v", struct {
+				Authority string
+				Algorithm string
+				Digest    string
+				Params    []NiParam
+			}{ni.Authority, ni.Algorithm, hex.EncodeToString(ni.Digest), ni.Params}))
+			equals(testCase.testName+"(String)", t, testCase.expectedString, ni.String())
+		})
+	}
+}
+
+func TestNiVerify(t *testing.T) {
+	type TestCase struct {
+		data        string
+		content     string
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{data: "ni:///sha-256;f4OxZX_x_FO5LcGBSKHWXfwtSx-j1ncoSt3SABJtkGk", content: "Hello World!"},
+		{data: "ni:///sha-256-32;f4OxZQ", content: "Hello World!"},
+		{data: "ni:///sha-256;f4OxZX_x_FO5LcGBSKHWXfwtSx-j1ncoSt3SABJtkGk", content: "Hello World", expectedErr: errNiDigestMismatch},
+		{data: "ni:///x-unknown;AQID", content: "Hello World!", expectedErr: errNiUnknownAlgorithm},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			ni, err := ParseNi([]byte(testCase.data))
+			if err != nil {
+				t.Errorf("Failed to parse: %v", err.Error())
+				return
+			}
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(ni.Verify([]byte(testCase.content))))
+		})
+	}
+}
+
+func TestNewNi(t *testing.T) {
+	ni, err := NewNi("example.com", "sha-256-128", []byte("Hello World!"))
+	equals("err", t, "<nil>", fmt.Sprint(err))
+	equals("String", t, "ni://example.com/sha-256-128;f4OxZX_x_FO5LcGBSKHWXQ", ni.String())
+
+	other, _ := ParseNi([]byte("ni:///sha-256-128;f4OxZX_x_FO5LcGBSKHWXQ?ct=text/plain"))
+	equals("Equivalent", t, true, ni.Equivalent(other))
+	equals("ContentType", t, "text/plain", other.ContentType())
+
+	_, err = NewNi("", "md5", []byte("Hello World!"))
+	equals("unknown", t, fmt.Sprint(errNiUnknownAlgorithm), fmt.Sprint(err))
+}
@@ -0,0 +1,160 @@
+package urip
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// defaultPorts are the default ports of the schemes which have a tuple
+// origin.
+var defaultPorts = map[string]string{
+	"ftp":   "21",
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// opaqueOriginCount is the last identifier of the opaque origins.
+var opaqueOriginCount uint64
+
+// Origin is a web origin, which is either a tuple of scheme, host and port,
+// or an opaque origin which is the same origin only with itself.
+type Origin struct {
+	Scheme string // in lowercase
+	Host   string // in lowercase
+	Port   string // the port without leading zeros, or the default port
+	opaque uint64 // a globally unique identifier of an opaque origin, 0 for a tuple
+}
+
+// Origin returns the origin of the URI.
+//
+// RFC6454 - 4. Origin of a URI
+//
+//  1. If the URI does not use a hierarchical element as a naming authority
+//     (see [RFC3986], Section 3.2) or if the URI is not an absolute URI, then
+//     generate a fresh globally unique identifier and return that value.
+//  2. If uri-scheme is "file", the implementation MAY return an
+//     implementation-defined value.
+//  3. Let uri-scheme be the scheme component of the URI, converted to
+//     lowercase.
+//  4. If the implementation doesn't support the protocol given by
+//     uri-scheme, then generate a fresh globally unique identifier and return
+//     that value.
+//  5. Let uri-host be the host component of the URI, converted to lower case.
+//  6. If there is no port component of the URI, let uri-port be the default
+//     port for the protocol given by uri-scheme. Otherwise, let uri-port be
+//     the port component of the URI.
+//  7. Return the triple (uri-scheme, uri-host, uri-port).
+//
+// The URI has an opaque origin if it has no host, or the scheme is "file" or
+// has no default port.
+//
+// The origin is of the components of the URI as they are. A URI returned by
+// Parse may be cut short, e.g. Parse finds host "127.0.0.1" in
+// "http://127.0.0.1.evil.example/". Use ParseOrigin for a URI which is not
+// trusted, such as the one compared for CORS.
+//
+// NOTE
+// The host is not converted by IDNA ToASCII, but the pct-encodings in the host
+// are normalized.
+func (uri *Uri) Origin() *Origin {
+	if origin, found := tupleOrigin(uri); found {
+		return origin
+	}
+	return &Origin{opaque: atomic.AddUint64(&opaqueOriginCount, 1)}
+}
+
+// ParseOrigin parses data as a URI, and returns its origin. Unlike
+// Parse(data).Origin(), it fails if data is not a URI as a whole, so the
+// authority is never cut short.
+func ParseOrigin(data []byte) (*Origin, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	return uri.Origin(), nil
+}
+
+// tupleOrigin returns the scheme/host/port triple of the URI, and whether the
+// URI has one. The scheme and the host are lowercased, and the port is the
+// effective port without leading zeros.
+//
+// RFC9110 - 4.3.1. URI Origin
+// The "origin" for a given URI is the triple of scheme, host, and port after
+// normalizing the scheme and host to lowercase and normalizing the port to
+// remove any leading zeros. If port is elided from the URI, the default port
+// for that scheme is used.
+func tupleOrigin(uri *Uri) (*Origin, bool) {
+	scheme := strings.ToLower(string(uri.Scheme))
+	if _, found := defaultPorts[scheme]; !found || len(uri.DoubleSlash) == 0 || len(uri.Host) == 0 {
+		return nil, false
+	}
+	port := strings.TrimLeft(uri.EffectivePort(), "0")
+	if port == "" {
+		port = "0"
+	}
+	return &Origin{
+		Scheme: scheme,
+		Host:   string(normalizePctEncoded([]byte(strings.ToLower(string(uri.Host))))),
+		Port:   port,
+	}, true
+}
+
+// Opaque reports whether the origin is an opaque origin.
+func (origin *Origin) Opaque() bool {
+	return origin.opaque != 0
+}
+
+// SameOrigin reports whether the origins are the same.
+//
+// RFC6454 - 5. Comparing Origins
+// Two origins are "the same" if, and only if, they are identical. In
+// particular:
+//
+//   - If the two origins are scheme/host/port triples, the two origins are
+//     the same if, and only if, they have identical schemes, hosts, and
+//     ports.
+//   - An origin that is a globally unique identifier cannot be the same as
+//     an origin that is a scheme/host/port triple.
+func (origin *Origin) SameOrigin(other *Origin) bool {
+	return *origin == *other
+}
+
+// String returns the ASCII serialization of the origin. The default port is
+// omitted.
+//
+// RFC6454 - 6.2. ASCII Serialization of an Origin
+//
+//  1. If the origin is not a scheme/host/port triple, then return the string
+//     null (i.e., the code point sequence U+006E, U+0075, U+006C, U+006C) and
+//     abort these steps.
+//  2. Otherwise, let result be the scheme part of the origin triple.
+//  3. Append the string "://" to result.
+//  4. Append each character of the host part of the origin triple (converted
+//     as required by the IDNA ToASCII algorithm) to result.
+//  5. If the port part of the origin triple is different from the default
+//     port for the protocol given by the scheme part of the origin triple:
+//     append a U+003A COLON code point (":") and the given port, in base ten,
+//     to result.
+//  6. Return result.
+func (origin *Origin) String() string {
+	if origin.Opaque() {
+		return "null"
+	}
+	str := origin.Scheme + "://" + origin.Host
+	if origin.Port != defaultPorts[origin.Scheme] {
+		str += ":" + origin.Port
+	}
+	return str
+}
+
+// EffectivePort returns the port, or the default port of the scheme if it is
+// omitted, such as 80 for http and ws, and 443 for https and wss. It returns
+// "" if the port is omitted and the scheme has no default port.
+func (uri *Uri) EffectivePort() string {
+	if len(uri.Port) > 0 {
+		return string(uri.Port)
+	}
+	return defaultPorts[strings.ToLower(string(uri.Scheme))]
+}
@@ -0,0 +1,188 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUriOrigin(t *testing.T) {
+	type TestCase struct {
+		testName       string
+		data           []byte
+		expectedOpaque bool
+		expectedString string
+	}
+
+	tests := []TestCase{
+		// RFC6454 - 3.2.1. Examples
+		{
+			testName:       "data: []byte(\"http://example.com/\")",
+			data:           []byte("http://example.com/"),
+			expectedString: "http://example.com",
+		},
+		{
+			testName:       "data: []byte(\"http://example.com:80/\")",
+			data:           []byte("http://example.com:80/"),
+			expectedString: "http://example.com",
+		},
+		{
+			testName:       "data: []byte(\"http://example.com:8080/\")",
+			data:           []byte("http://example.com:8080/"),
+			expectedString: "http://example.com:8080",
+		},
+		{
+			testName:       "data: []byte(\"HTTPS://User@Example.COM:0443/path?q#f\")",
+			data:           []byte("HTTPS://User@Example.COM:0443/path?q#f"),
+			expectedString: "https://example.com",
+		},
+		{
+			testName:       "data: []byte(\"wss://[::1]:8443/chat\")",
+			data:           []byte("wss://[::1]:8443/chat"),
+			expectedString: "wss://[::1]:8443",
+		},
+		{
+			testName:       "data: []byte(\"ftp://ftp.example.com/pub\")",
+			data:           []byte("ftp://ftp.example.com/pub"),
+			expectedString: "ftp://ftp.example.com",
+		},
+		{
+			testName:       "data: []byte(\"data:text/plain,hello\")",
+			data:           []byte("data:text/plain,hello"),
+			expectedOpaque: true,
+			expectedString: "null",
+		},
+		{
+			testName:       "data: []byte(\"file:///etc/hosts\")",
+			data:           []byte("file:///etc/hosts"),
+			expectedOpaque: true,
+			expectedString: "null",
+		},
+		{
+			testName:       "data: []byte(\"file://server/share\")",
+			data:           []byte("file://server/share"),
+			expectedOpaque: true,
+			expectedString: "null",
+		},
+		{
+			testName:       "data: []byte(\"http:///path\")",
+			data:           []byte("http:///path"),
+			expectedOpaque: true,
+			expectedString: "null",
+		},
+		{
+			testName:       "data: []byte(\"foo://example.com/\")",
+			data:           []byte("foo://example.com/"),
+			expectedOpaque: true,
+			expectedString: "null",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			uri, err := Parse(testCase.data)
+			if err != nil {
+				t.Errorf("Failed to parse: %v", err.Error())
+				return
+			}
+			origin := uri.Origin()
+			equals(testCase.testName+"(Opaque)", t, testCase.expectedOpaque, origin.Opaque())
+			equals(testCase.testName+"(String)", t, testCase.expectedString, origin.String())
+		})
+	}
+}
+
+func TestOriginSameOrigin(t *testing.T) {
+	type TestCase struct {
+		data1    string
+		data2    string
+		expected bool
+	}
+
+	tests := []TestCase{
+		// RFC6454 - 3.2.1. Examples
+		{data1: "http://example.com/", data2: "http://example.com:80/", expected: true},
+		{data1: "http://example.com/", data2: "http://example.com/path/file", expected: true},
+		{data1: "http://example.com/", data2: "http://example.com:8080/", expected: false},
+		{data1: "http://example.com/", data2: "https://example.com/", expected: false},
+		{data1: "http://example.com/", data2: "http://www.example.com/", expected: false},
+		{data1: "HTTP://EXAMPLE.COM/", data2: "http://example.com:0080/", expected: true},
+		{data1: "data:,a", data2: "data:,a", expected: false},
+		{data1: "data:,a", data2: "http://example.com/", expected: false},
+	}
+
+	for _, testCase := range tests {
+		testName := testCase.data1 + " " + testCase.data2
+		t.Run(testName, func(t *testing.T) {
+			uri1, err := Parse([]byte(testCase.data1))
+			if err != nil {
+				t.Errorf("Failed to parse data1: %v", err.Error())
+				return
+			}
+			uri2, err := Parse([]byte(testCase.data2))
+			if err != nil {
+				t.Errorf("Failed to parse data2: %v", err.Error())
+				return
+			}
+			equals(testName, t, testCase.expected, uri1.Origin().SameOrigin(uri2.Origin()))
+		})
+	}
+
+	uri, _ := Parse([]byte("data:,a"))
+	origin := uri.Origin()
+	equals("opaque itself", t, true, origin.SameOrigin(origin))
+}
+
+func TestParseOrigin(t *testing.T) {
+	type TestCase struct {
+		data           string
+		expectedString string
+		expectedErr    error
+	}
+
+	tests := []TestCase{
+		{data: "https://Example.com:0443/path?q#f", expectedString: "https://example.com"},
+		{data: "http://127.0.0.1.evil.example/", expectedString: "http://127.0.0.1.evil.example"},
+		{data: "http://127.0.0.1/", expectedString: "http://127.0.0.1"},
+		{data: "data:,a", expectedString: "null"},
+		{data: "http://exa mple.com/", expectedErr: errTrailingData},
+		{data: "http://example.com\\@evil.example/", expectedErr: errTrailingData},
+		{data: "example.com", expectedErr: errColonNotFound},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			origin, err := ParseOrigin([]byte(testCase.data))
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.data, t, testCase.expectedString, origin.String())
+		})
+	}
+
+	// Parse cuts the host short, but ParseOrigin does not.
+	evil, _ := ParseOrigin([]byte("http://127.0.0.1.evil.example/"))
+	local, _ := ParseOrigin([]byte("http://127.0.0.1"))
+	equals("same origin", t, false, evil.SameOrigin(local))
+}
+
+func TestUriEffectivePort(t *testing.T) {
+	ports := map[string]string{
+		"http://example.com/":      "80",
+		"HTTPS://example.com/":     "443",
+		"wss://example.com/":       "443",
+		"ftp://example.com/":       "21",
+		"http://example.com:8080/": "8080",
+		"urn:isbn:0451450523":      "",
+		"foo://example.com/":       "",
+		"foo://example.com:1234/":  "1234",
+	}
+	for data, port := range ports {
+		uri, err := Parse([]byte(data))
+		if err != nil {
+			t.Errorf("Failed to parse Uri: %v", err.Error())
+			continue
+		}
+		equals(data, t, port, uri.EffectivePort())
+	}
+}
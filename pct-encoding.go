@@ -0,0 +1,79 @@
+package urip
+
+import (
+	"errors"
+)
+
+var errInvalidPctEncoded = errors.New("invalid pct-encoded.")
+
+// RFC3986 - 2.1. Percent-Encoding
+//
+//  pct-encoded   = "%" HEXDIG HEXDIG
+//
+
+// decodePctEncoded replaces each pct-encoded in data with the octet it
+// represents.
+func decodePctEncoded(data []byte) ([]byte, error) {
+	decoded := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '%' {
+			decoded = append(decoded, data[i])
+			continue
+		}
+		if !isPctEncodedNoCase(data, i) {
+			return nil, errInvalidPctEncoded
+		}
+		decoded = append(decoded, hexValue(data[i+1])<<4|hexValue(data[i+2]))
+		i += 2
+	}
+	return decoded, nil
+}
+
+// encodePctEncoded replaces each octet in data which is not allowed with
+// pct-encoded. RFC3986 - 2.1 says that URI producers should use uppercase
+// hexadecimal digits.
+func encodePctEncoded(data []byte, allowed func(c byte) bool) []byte {
+	const hexDigits = "0123456789ABCDEF"
+	encoded := make([]byte, 0, len(data))
+	for _, c := range data {
+		if allowed(c) {
+			encoded = append(encoded, c)
+			continue
+		}
+		encoded = append(encoded, '%', hexDigits[c>>4], hexDigits[c&0x0f])
+	}
+	return encoded
+}
+
+func hexValue(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// normalizePctEncoded uppercases the hexadecimal digits of each pct-encoded
+// in data, as RFC3986 - 6.2.2.1 Case Normalization does.
+func normalizePctEncoded(data []byte) []byte {
+	normalized := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '%' || !isPctEncodedNoCase(data, i) {
+			normalized = append(normalized, data[i])
+			continue
+		}
+		normalized = append(normalized, '%', toUpper(data[i+1]), toUpper(data[i+2]))
+		i += 2
+	}
+	return normalized
+}
+
+func toUpper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
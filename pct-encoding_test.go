@@ -0,0 +1,61 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodePctEncoded(t *testing.T) {
+	type TestCase struct {
+		testName    string
+		data        []byte
+		expected    []byte
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{
+			testName: "data: []byte{}",
+			data:     []byte{},
+			expected: []byte{},
+		},
+		{
+			testName: "data: []byte(\"a%20b\")",
+			data:     []byte("a%20b"),
+			expected: []byte("a b"),
+		},
+		{
+			testName: "data: []byte(\"%E3%81%82%e3%81%84\")",
+			data:     []byte("%E3%81%82%e3%81%84"),
+			expected: []byte("あい"),
+		},
+		{
+			testName:    "data: []byte(\"%2\")",
+			data:        []byte("%2"),
+			expectedErr: errInvalidPctEncoded,
+		},
+		{
+			testName:    "data: []byte(\"%zz\")",
+			data:        []byte("%zz"),
+			expectedErr: errInvalidPctEncoded,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			decoded, err := decodePctEncoded(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			byteEquals(testCase.testName, t, testCase.expected, decoded)
+		})
+	}
+}
+
+func TestEncodePctEncoded(t *testing.T) {
+	encoded := encodePctEncoded([]byte("a b/あ%"), isUnreserved)
+	byteEquals("encodePctEncoded", t, []byte("a%20b%2F%E3%81%82%25"), encoded)
+}
+
+func TestNormalizePctEncoded(t *testing.T) {
+	normalized := normalizePctEncoded([]byte("ab%2c%e3%81%82%2"))
+	byteEquals("normalizePctEncoded", t, []byte("ab%2C%E3%81%82%2"), normalized)
+}
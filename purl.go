@@ -0,0 +1,272 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	errPurlSchemeNotFound       = errors.New("pkg scheme not found.")
+	errPurlTypeNotFound         = errors.New("type of purl not found.")
+	errPurlNamespaceNotFound    = errors.New("namespace of purl not found.")
+	errPurlNameNotFound         = errors.New("name of purl not found.")
+	errPurlQualifierKeyNotFound = errors.New("qualifier key of purl not found.")
+	errPurlDuplicateQualifier   = errors.New("qualifier key of purl appears more than once.")
+)
+
+// Purl is a package URL. All the fields are decoded.
+type Purl struct {
+	Type       string // in lowercase
+	Namespace  string // "" if it is omitted, the segments are separated with "/"
+	Name       string
+	Version    string // "" if it is omitted
+	Qualifiers []PurlQualifier
+	Subpath    string // "" if it is omitted, the segments are separated with "/"
+}
+
+type PurlQualifier struct {
+	Key   string // in lowercase
+	Value string
+}
+
+// ParsePurl parses a package URL, and normalizes it with the rules of the
+// type.
+//
+// package-url specification - A purl is a URL
+//
+//	scheme:type/namespace/name@version?qualifiers#subpath
+//
+// The "//" following the scheme, the empty segments and the qualifiers with
+// empty values are ignored. "." and ".." are also ignored in subpath.
+//
+// NOTE
+// The spec splits version at the last "@", but "@" is searched only in the
+// last segment, so that an npm scope with "@" not pct-encoded is accepted.
+func ParsePurl(data []byte) (*Purl, error) {
+	if colon := bytes.IndexByte(data, ':'); colon >= 0 {
+		rest := bytes.TrimLeft(data[colon+1:], "/")
+		data = append(append([]byte{}, data[:colon+1]...), rest...)
+	}
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("pkg")) {
+		return nil, errPurlSchemeNotFound
+	}
+
+	purl := &Purl{}
+	path := strings.Trim(string(uri.Path), "/")
+	purl.Type, path, _ = strings.Cut(path, "/")
+	purl.Type = strings.ToLower(purl.Type)
+
+	lastSlash := strings.LastIndexByte(path, '/')
+	if at := strings.LastIndexByte(path[lastSlash+1:], '@'); at >= 0 {
+		version, err := decodePctEncoded([]byte(path[lastSlash+1+at+1:]))
+		if err != nil {
+			return nil, err
+		}
+		purl.Version = string(version)
+		path = path[:lastSlash+1+at]
+	}
+	segments, err := decodePurlSegments(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 0 {
+		purl.Name = segments[len(segments)-1]
+		purl.Namespace = strings.Join(segments[:len(segments)-1], "/")
+		for _, segment := range segments[:len(segments)-1] {
+			if strings.IndexByte(segment, '/') >= 0 {
+				return nil, errPurlNamespaceNotFound
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, qualifier := range strings.Split(string(uri.Query), "&") {
+		key, value, _ := strings.Cut(qualifier, "=")
+		if value == "" {
+			continue
+		}
+		key = strings.ToLower(key)
+		if !isPurlQualifierKey(key) {
+			return nil, errPurlQualifierKeyNotFound
+		}
+		if seen[key] {
+			return nil, errPurlDuplicateQualifier
+		}
+		seen[key] = true
+		decoded, err := decodePctEncoded([]byte(value))
+		if err != nil {
+			return nil, err
+		}
+		purl.Qualifiers = append(purl.Qualifiers, PurlQualifier{Key: key, Value: string(decoded)})
+	}
+
+	subpath, err := decodePurlSegments(string(uri.Fragment))
+	if err != nil {
+		return nil, err
+	}
+	segments = []string{}
+	for _, segment := range subpath {
+		if segment != "." && segment != ".." {
+			segments = append(segments, segment)
+		}
+	}
+	purl.Subpath = strings.Join(segments, "/")
+
+	if err := purl.Validate(); err != nil {
+		return nil, err
+	}
+	purl.normalize()
+	return purl, nil
+}
+
+// Validate reports whether the fields are valid for a package URL, such as
+// the one built as a struct.
+func (purl *Purl) Validate() error {
+	if !isPurlType(purl.Type) {
+		return errPurlTypeNotFound
+	}
+	if purl.Name == "" {
+		return errPurlNameNotFound
+	}
+	// package-url specification - PURL Types - maven
+	//
+	//	The group id is the namespace and the artifact id is the name.
+	if strings.ToLower(purl.Type) == "maven" && purl.Namespace == "" {
+		return errPurlNamespaceNotFound
+	}
+	for _, qualifier := range purl.Qualifiers {
+		if !isPurlQualifierKey(strings.ToLower(qualifier.Key)) {
+			return errPurlQualifierKeyNotFound
+		}
+	}
+	return nil
+}
+
+// String encodes the package URL in the canonical form. The rules of the type
+// are applied, and the qualifiers are sorted by the keys.
+func (purl *Purl) String() string {
+	canonical := *purl
+	canonical.Qualifiers = append([]PurlQualifier{}, purl.Qualifiers...)
+	canonical.normalize()
+
+	str := "pkg:" + canonical.Type + "/"
+	if canonical.Namespace != "" {
+		str += encodePurlSegments(canonical.Namespace) + "/"
+	}
+	str += string(encodePctEncoded([]byte(canonical.Name), isPurlChar))
+	if canonical.Version != "" {
+		str += "@" + string(encodePctEncoded([]byte(canonical.Version), isPurlChar))
+	}
+	qualifiers := []string{}
+	for _, qualifier := range canonical.Qualifiers {
+		if qualifier.Value == "" {
+			continue
+		}
+		qualifiers = append(qualifiers, qualifier.Key+"="+string(encodePctEncoded([]byte(qualifier.Value), func(c byte) bool {
+			return isPurlChar(c) || c == '/'
+		})))
+	}
+	if len(qualifiers) > 0 {
+		str += "?" + strings.Join(qualifiers, "&")
+	}
+	if canonical.Subpath != "" {
+		str += "#" + encodePurlSegments(canonical.Subpath)
+	}
+	return str
+}
+
+// normalize applies the rules of the type of package-url specification -
+// PURL Types.
+func (purl *Purl) normalize() {
+	purl.Type = strings.ToLower(purl.Type)
+	switch purl.Type {
+	case "npm", "github", "bitbucket":
+		// The namespace and the name are case insensitive and lowercased.
+		purl.Namespace = strings.ToLower(purl.Namespace)
+		purl.Name = strings.ToLower(purl.Name)
+	case "pypi":
+		// The name must be lowercased and underscore "_" replaced with a
+		// dash "-".
+		purl.Name = strings.ReplaceAll(strings.ToLower(purl.Name), "_", "-")
+	}
+	for i := range purl.Qualifiers {
+		purl.Qualifiers[i].Key = strings.ToLower(purl.Qualifiers[i].Key)
+	}
+	sort.SliceStable(purl.Qualifiers, func(i, j int) bool {
+		return purl.Qualifiers[i].Key < purl.Qualifiers[j].Key
+	})
+}
+
+// decodePurlSegments splits path with "/", and decodes the segments. The
+// empty segments are discarded.
+func decodePurlSegments(path string) ([]string, error) {
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		decoded, err := decodePctEncoded([]byte(segment))
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, string(decoded))
+	}
+	return segments, nil
+}
+
+func encodePurlSegments(path string) string {
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, string(encodePctEncoded([]byte(segment), isPurlChar)))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isPurlChar(c byte) bool {
+	return isUnreserved(c) || c == ':'
+}
+
+// package-url specification - Rules for each purl component
+//
+//	The package "type" is composed only of ASCII letters and numbers, "."
+//	(period), "+" (plus), and "-" (dash). The "type" cannot start with a
+//	number.
+
+func isPurlType(str string) bool {
+	if str == "" || isDigit(str[0]) {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if !isAlpha(c) && !isDigit(c) && c != '.' && c != '+' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// package-url specification - Rules for each purl component
+//
+//	The key must be composed only of ASCII letters and numbers, "." (period),
+//	"-" (dash) and "_" (underscore). A key cannot start with a number.
+
+func isPurlQualifierKey(str string) bool {
+	if str == "" || isDigit(str[0]) {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if !isAlpha(c) && !isDigit(c) && c != '.' && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParsePurl(t *testing.T) {
+	type TestCase struct {
+		data           string
+		expected       Purl
+		expectedString string
+		expectedErr    error
+	}
+
+	tests := []TestCase{
+		// package-url specification - Some purl examples
+		{
+			data:           "pkg:bitbucket/birkenfeld/pygments-main@244fd47e07d1014f0aed9c",
+			expected:       Purl{Type: "bitbucket", Namespace: "birkenfeld", Name: "pygments-main", Version: "244fd47e07d1014f0aed9c"},
+			expectedString: "pkg:bitbucket/birkenfeld/pygments-main@244fd47e07d1014f0aed9c",
+		},
+		{
+			data:           "pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie",
+			expected:       Purl{Type: "deb", Namespace: "debian", Name: "curl", Version: "7.50.3-1", Qualifiers: []PurlQualifier{{Key: "arch", Value: "i386"}, {Key: "distro", Value: "jessie"}}},
+			expectedString: "pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie",
+		},
+		{
+			data:           "pkg:golang/google.golang.org/genproto#googleapis/api/annotations",
+			expected:       Purl{Type: "golang", Namespace: "google.golang.org", Name: "genproto", Subpath: "googleapis/api/annotations"},
+			expectedString: "pkg:golang/google.golang.org/genproto#googleapis/api/annotations",
+		},
+		{
+			data:           "pkg:maven/org.apache.xmlgraphics/batik-anim@1.9.1?packaging=sources",
+			expected:       Purl{Type: "maven", Namespace: "org.apache.xmlgraphics", Name: "batik-anim", Version: "1.9.1", Qualifiers: []PurlQualifier{{Key: "packaging", Value: "sources"}}},
+			expectedString: "pkg:maven/org.apache.xmlgraphics/batik-anim@1.9.1?packaging=sources",
+		},
+		{
+			data:           "pkg:npm/%40angular/animation@12.3.1",
+			expected:       Purl{Type: "npm", Namespace: "@angular", Name: "animation", Version: "12.3.1"},
+			expectedString: "pkg:npm/%40angular/animation@12.3.1",
+		},
+		// normalization
+		{
+			data:           "PKG://NPM/@Angular/Core@12.0.0",
+			expected:       Purl{Type: "npm", Namespace: "@angular", Name: "core", Version: "12.0.0"},
+			expectedString: "pkg:npm/%40angular/core@12.0.0",
+		},
+		{
+			data:           "pkg:pypi/Django_Rest_Framework@3.14.0",
+			expected:       Purl{Type: "pypi", Name: "django-rest-framework", Version: "3.14.0"},
+			expectedString: "pkg:pypi/django-rest-framework@3.14.0",
+		},
+		{
+			data:           "pkg:maven//org.apache.commons//io/?Repository_URL=repo.spring.io%2Frelease&classifier=&Type=jar#/./sub/../dir/",
+			expected:       Purl{Type: "maven", Namespace: "org.apache.commons", Name: "io", Qualifiers: []PurlQualifier{{Key: "repository_url", Value: "repo.spring.io/release"}, {Key: "type", Value: "jar"}}, Subpath: "sub/dir"},
+			expectedString: "pkg:maven/org.apache.commons/io?repository_url=repo.spring.io/release&type=jar#sub/dir",
+		},
+		{
+			data:           "pkg:generic/openssl@1.1.10g?download_url=https://openssl.org/source/openssl-1.1.0g.tar.gz",
+			expected:       Purl{Type: "generic", Name: "openssl", Version: "1.1.10g", Qualifiers: []PurlQualifier{{Key: "download_url", Value: "https://openssl.org/source/openssl-1.1.0g.tar.gz"}}},
+			expectedString: "pkg:generic/openssl@1.1.10g?download_url=https://openssl.org/source/openssl-1.1.0g.tar.gz",
+		},
+		{data: "urn:npm/foo", expectedErr: errPurlSchemeNotFound},
+		{data: "pkg:1npm/foo", expectedErr: errPurlTypeNotFound},
+		{data: "pkg:npm", expectedErr: errPurlNameNotFound},
+		{data: "pkg:npm/@1.0", expectedErr: errPurlNameNotFound},
+		{data: "pkg:maven/commons-io@2.11.0", expectedErr: errPurlNamespaceNotFound},
+		{data: "pkg:npm/a%2Fb/foo", expectedErr: errPurlNamespaceNotFound},
+		{data: "pkg:npm/foo?1a=b", expectedErr: errPurlQualifierKeyNotFound},
+		{data: "pkg:npm/foo?a=b&A=c", expectedErr: errPurlDuplicateQualifier},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			purl, err := ParsePurl([]byte(testCase.data))
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.data, t, fmt.Sprintf("%+v", testCase.expected), fmt.Sprintf("%+v", *purl))
+			equals(testCase.data+"(String)", t, testCase.expectedString, purl.String())
+		})
+	}
+}
+
+func TestPurlString(t *testing.T) {
+	purl := Purl{
+		Type:       "PyPI",
+		Name:       "Typing_Extensions",
+		Version:    "4.0.0 rc1",
+		Qualifiers: []PurlQualifier{{Key: "os", Value: "linux"}, {Key: "Arch", Value: "x86_64"}, {Key: "empty"}},
+	}
+	equals("Validate", t, "<nil>", fmt.Sprint(purl.Validate()))
+	equals("String", t, "pkg:pypi/typing-extensions@4.0.0%20rc1?arch=x86_64&os=linux", purl.String())
+	equals("Name", t, "Typing_Extensions", purl.Name)
+
+	purl = Purl{Type: "maven", Name: "guava"}
+	equals("Validate", t, fmt.Sprint(errPurlNamespaceNotFound), fmt.Sprint(purl.Validate()))
+}
@@ -0,0 +1,188 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+
+	abnfp "github.com/um7a/abnf-parser"
+)
+
+var (
+	errOriginFormNotFound     = errors.New("origin-form not found.")
+	errAuthorityFormNotFound  = errors.New("authority-form not found.")
+	errAsteriskFormNotAllowed = errors.New("asterisk-form is allowed only for OPTIONS.")
+	errRequestTargetFragment  = errors.New("request-target cannot have fragment.")
+)
+
+// RequestTargetForm is the form of a request-target.
+type RequestTargetForm int
+
+const (
+	OriginForm RequestTargetForm = iota
+	AbsoluteForm
+	AuthorityForm
+	AsteriskForm
+)
+
+func (form RequestTargetForm) String() string {
+	switch form {
+	case AbsoluteForm:
+		return "absolute-form"
+	case AuthorityForm:
+		return "authority-form"
+	case AsteriskForm:
+		return "asterisk-form"
+	}
+	return "origin-form"
+}
+
+// RequestTarget is a request-target of a request-line. Only the components of
+// the form are set: Path and Query for origin-form, all the components but
+// Fragment for absolute-form, and Host and Port for authority-form.
+type RequestTarget struct {
+	Form RequestTargetForm
+	Uri
+}
+
+// ParseRequestTarget parses a request-target of a request with the method.
+//
+// RFC9112 - 3.2. Request Target
+//
+//	request-target = origin-form
+//	               / absolute-form
+//	               / authority-form
+//	               / asterisk-form
+//	origin-form    = absolute-path [ "?" query ]
+//	absolute-form  = absolute-URI
+//	authority-form = uri-host ":" port
+//	asterisk-form  = "*"
+//
+// authority-form is used only for CONNECT, and asterisk-form only for
+// OPTIONS. The method is case sensitive.
+//
+// NOTE
+// The method is required, since "host:port" is also an absolute-URI whose
+// scheme is "host".
+func ParseRequestTarget(method string, data []byte) (*RequestTarget, error) {
+	if method == "CONNECT" {
+		return parseAuthorityForm(data)
+	}
+	if string(data) == "*" {
+		if method != "OPTIONS" {
+			return nil, errAsteriskFormNotAllowed
+		}
+		return &RequestTarget{Form: AsteriskForm}, nil
+	}
+	if len(data) > 0 && data[0] == '/' {
+		return parseOriginForm(data)
+	}
+
+	// RFC3986 - 4.3. Absolute URI
+	//
+	//  absolute-URI  = scheme ":" hier-part [ "?" query ]
+	//
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(uri.Sharp) > 0 {
+		return nil, errRequestTargetFragment
+	}
+	return &RequestTarget{Form: AbsoluteForm, Uri: *uri}, nil
+}
+
+// parseOriginForm parses origin-form.
+//
+// RFC9110 - 4.1. URI References
+//
+//	absolute-path = 1*( "/" segment )
+//
+// NOTE
+// absolute-path is path-abempty which is not empty. path-absolute is not
+// used, since absolute-path may start with "//".
+func parseOriginForm(data []byte) (*RequestTarget, error) {
+	target := &RequestTarget{Form: OriginForm}
+	parsed, remaining := abnfp.Parse(data, NewPathAbemptyFinder())
+	if len(parsed) == 0 {
+		return nil, errOriginFormNotFound
+	}
+	target.Path = parsed
+
+	// [ "?" query ]
+	parsed, remaining = abnfp.Parse(remaining, abnfp.NewOptionalSequenceFinder(
+		abnfp.NewConcatenationFinder([]abnfp.Finder{
+			abnfp.NewByteFinder('?'),
+			NewQueryFinder(),
+		}),
+	))
+	if len(parsed) > 0 {
+		target.Question = []byte("?")
+		target.Query = parsed[1:]
+	}
+	if len(remaining) > 0 {
+		if remaining[0] == '#' {
+			return nil, errRequestTargetFragment
+		}
+		return nil, errOriginFormNotFound
+	}
+	return target, nil
+}
+
+// parseAuthorityForm parses authority-form. The port is required, since
+// CONNECT has no default port.
+//
+// NOTE
+// The IP-literal is validated by isIpV6Address and isIpVFuture instead of
+// NewHostFinder, since the finders do not backtrack, and NewIpV6AddressFinder
+// does not find an address such as "2001:db8::1". For the same reason,
+// NewHostFinder finds only "1.2.3.4" in "1.2.3.4.example.com", so the host is
+// found again as reg-name if it is not followed by ":".
+func parseAuthorityForm(data []byte) (*RequestTarget, error) {
+	target := &RequestTarget{Form: AuthorityForm}
+
+	// RFC3986 - 3.2.2. Host
+	//
+	//  host = IP-literal / IPv4address / reg-name
+	//
+	var parsed, remaining []byte
+	if len(data) > 0 && data[0] == '[' {
+		end := bytes.IndexByte(data, ']')
+		if end < 0 || !(isIpV6Address(data[1:end]) || isIpVFuture(data[1:end])) {
+			return nil, errAuthorityFormNotFound
+		}
+		parsed, remaining = data[:end+1], data[end+1:]
+	} else {
+		parsed, remaining = abnfp.Parse(data, NewHostFinder())
+		if len(remaining) == 0 || remaining[0] != ':' {
+			parsed, remaining = abnfp.Parse(data, NewRegNameFinder())
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, errAuthorityFormNotFound
+	}
+	target.Host = parsed
+
+	// ":" port
+	parsed, remaining = abnfp.Parse(remaining, abnfp.NewConcatenationFinder([]abnfp.Finder{
+		abnfp.NewByteFinder(':'),
+		NewPortFinder(),
+	}))
+	if len(parsed) <= 1 || len(remaining) > 0 {
+		return nil, errAuthorityFormNotFound
+	}
+	target.Port = parsed[1:]
+	return target, nil
+}
+
+// String encodes the request-target in its form.
+func (target *RequestTarget) String() string {
+	switch target.Form {
+	case AbsoluteForm:
+		return target.Uri.String()
+	case AuthorityForm:
+		return string(target.Host) + ":" + string(target.Port)
+	case AsteriskForm:
+		return "*"
+	}
+	return string(target.Path) + string(target.Question) + string(target.Query)
+}
@@ -0,0 +1,64 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseRequestTarget(t *testing.T) {
+	type TestCase struct {
+		method       string
+		data         string
+		expectedForm RequestTargetForm
+		expectedHost string
+		expectedPort string
+		expectedPath string
+		expectedErr  error
+	}
+
+	// RFC9112 - 3.2. Request Target
+	tests := []TestCase{
+		{method: "GET", data: "/where?q=now", expectedForm: OriginForm, expectedPath: "/where"},
+		{method: "GET", data: "/", expectedForm: OriginForm, expectedPath: "/"},
+		{method: "GET", data: "//double/slash?", expectedForm: OriginForm, expectedPath: "//double/slash"},
+		{method: "GET", data: "http://www.example.org/pub/WWW/TheProject.html", expectedForm: AbsoluteForm, expectedHost: "www.example.org", expectedPath: "/pub/WWW/TheProject.html"},
+		{method: "GET", data: "www.example.com:80", expectedForm: AbsoluteForm, expectedPath: "80"},
+		{method: "CONNECT", data: "www.example.com:80", expectedForm: AuthorityForm, expectedHost: "www.example.com", expectedPort: "80"},
+		{method: "CONNECT", data: "[2001:db8::1]:443", expectedForm: AuthorityForm, expectedHost: "[2001:db8::1]", expectedPort: "443"},
+		{method: "CONNECT", data: "192.0.2.1:8443", expectedForm: AuthorityForm, expectedHost: "192.0.2.1", expectedPort: "8443"},
+		{method: "CONNECT", data: "1.2.3.4.example.com:443", expectedForm: AuthorityForm, expectedHost: "1.2.3.4.example.com", expectedPort: "443"},
+		{method: "CONNECT", data: "10.0.0.1x:443", expectedForm: AuthorityForm, expectedHost: "10.0.0.1x", expectedPort: "443"},
+		{method: "OPTIONS", data: "*", expectedForm: AsteriskForm},
+		{method: "OPTIONS", data: "/index.html", expectedForm: OriginForm, expectedPath: "/index.html"},
+		{method: "GET", data: "*", expectedErr: errAsteriskFormNotAllowed},
+		{method: "options", data: "*", expectedErr: errAsteriskFormNotAllowed},
+		{method: "GET", data: "/a b", expectedErr: errOriginFormNotFound},
+		{method: "GET", data: "/a#f", expectedErr: errRequestTargetFragment},
+		{method: "GET", data: "http://example.com/#f", expectedErr: errRequestTargetFragment},
+		{method: "GET", data: "", expectedErr: errSchemeNotFound},
+		{method: "CONNECT", data: "www.example.com", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: "10.0.0.1", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: "10.0.0.1 x:443", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: "www.example.com:", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: ":443", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: "[2001:db8::g]:443", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: "www.example.com:443/", expectedErr: errAuthorityFormNotFound},
+		{method: "CONNECT", data: "/index.html", expectedErr: errAuthorityFormNotFound},
+	}
+
+	for _, testCase := range tests {
+		testName := testCase.method + " " + testCase.data
+		t.Run(testName, func(t *testing.T) {
+			target, err := ParseRequestTarget(testCase.method, []byte(testCase.data))
+			equals(testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testName+"(Form)", t, testCase.expectedForm.String(), target.Form.String())
+			equals(testName+"(Host)", t, testCase.expectedHost, string(target.Host))
+			equals(testName+"(Port)", t, testCase.expectedPort, string(target.Port))
+			equals(testName+"(Path)", t, testCase.expectedPath, string(target.Path))
+			equals(testName+"(String)", t, testCase.data, target.String())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Match is a URI found by Scanner.
+type Match struct {
+	Uri   []byte  // valid until the next call of Scan
+	View  UriView // offsets into Uri
+	Start int64   // offset of Uri in the stream
+	End   int64
+	// Truncated is true if the URI is longer than MaxUriSize and Uri is
+	// cut at that size.
+	Truncated bool
+}
+
+// Scanner finds the URIs embedded in free text such as logs, emails and
+// chat messages.
+//
+// A URI is accepted if it has an authority with a host, or if its scheme is
+// one of OpaqueSchemes. So "key:value" or "error: timeout" are not found.
+//
+// RFC3986 - Appendix C. Delimiting a URI in Context
+// URIs are often written in "<" and ">" or in double-quotes, and are
+// followed by punctuation in text. Since none of "<", ">" and `"` can
+// be in a URI, the end of the URI is found by the syntax. "<URL:" prefix is
+// skipped. The punctuation at the end, and ")" which does not have its
+// "(" in the URI, are taken as the text surrounding the URI.
+type Scanner struct {
+	// OpaqueSchemes are the lowercase schemes accepted without authority.
+	OpaqueSchemes []string
+	// MaxUriSize is the maximum size of a URI. A longer URI is cut at this
+	// size, and the rest of it is skipped.
+	MaxUriSize int
+
+	reader io.Reader
+	buf    []byte
+	offset int64 // offset of buf[0] in the stream
+	pos    int   // where to scan from in buf
+	eof    bool
+	err    error
+	match  Match
+	skip   bool // the rest of a truncated URI is at pos
+}
+
+const scannerReadSize = 4096
+
+func NewScanner(reader io.Reader) *Scanner {
+	return &Scanner{
+		OpaqueSchemes: []string{"data", "geo", "magnet", "mailto", "news", "sip", "sips", "tel", "urn"},
+		MaxUriSize:    64 * 1024,
+		reader:        reader,
+	}
+}
+
+// Scan finds the next URI, which will then be available through Match.
+// It returns false when the scan stops, either by reaching the end of the
+// input or an error.
+func (scanner *Scanner) Scan() bool {
+	for {
+		found, more := scanner.next()
+		if found {
+			return true
+		}
+		if !more {
+			return false
+		}
+		if !scanner.fill() {
+			return false
+		}
+	}
+}
+
+func (scanner *Scanner) Match() Match {
+	return scanner.match
+}
+
+// Err returns the first error, except io.EOF, which the reader returned.
+func (scanner *Scanner) Err() error {
+	return scanner.err
+}
+
+// next finds a URI in the buffer. If it cannot decide whether a URI is
+// there without reading more data, more is true.
+func (scanner *Scanner) next() (found bool, more bool) {
+	buf := scanner.buf
+	if scanner.skip {
+		scanner.pos += scanUriChars(buf[scanner.pos:])
+		if scanner.pos == len(buf) && !scanner.eof {
+			return false, true
+		}
+		scanner.skip = false
+	}
+	for i := scanner.pos; i < len(buf); i++ {
+		// A scheme starts at ALPHA which does not follow another byte of
+		// scheme.
+		if !isAlpha(buf[i]) || (i > 0 && hasClass(buf[i-1], classScheme)) {
+			continue
+		}
+		schemeEnd := i + 1
+		for schemeEnd < len(buf) && hasClass(buf[schemeEnd], classScheme) {
+			schemeEnd++
+		}
+		if scanner.needMore(i, schemeEnd) {
+			scanner.pos = i
+			return false, true
+		}
+
+		// RFC3986 - Appendix C. Delimiting a URI in Context
+		//
+		//  <URL:http://www.example.com/>
+		//
+		if i > 0 && buf[i-1] == '<' && bytes.EqualFold(buf[i:schemeEnd], []byte("URL")) &&
+			schemeEnd < len(buf) && buf[schemeEnd] == ':' {
+			i = schemeEnd
+			continue
+		}
+
+		var view UriView
+		if err := ParseInto(buf[i:], &view); err != nil {
+			i = schemeEnd - 1
+			continue
+		}
+		if scanner.needMore(i, i+view.End) {
+			scanner.pos = i
+			return false, true
+		}
+		uriEnd := i + view.End
+		end := i + trimUriInText(buf[i:uriEnd], view.Scheme.End)
+		truncated := end-i > scanner.MaxUriSize
+		if truncated {
+			end = i + scanner.MaxUriSize
+		}
+		if err := ParseInto(buf[i:end], &view); err != nil || !scanner.accept(buf[i:end], &view) {
+			i = schemeEnd - 1
+			continue
+		}
+
+		scanner.match = Match{
+			Uri:       buf[i:end],
+			View:      view,
+			Start:     scanner.offset + int64(i),
+			End:       scanner.offset + int64(end),
+			Truncated: truncated,
+		}
+		scanner.pos = end
+		if truncated {
+			// NOTE
+			// The rest is not scanned, or a URI in it, such as the one in
+			// "?next=https://...", would be found. needMore does not wait
+			// for the end of a truncated URI, so it may continue in the data
+			// not read yet.
+			scanner.pos = uriEnd
+			scanner.skip = uriEnd == len(buf) && !scanner.eof
+		}
+		return true, false
+	}
+	scanner.pos = len(buf)
+	return false, !scanner.eof
+}
+
+// needMore reports whether the syntax starting at start and ending at end
+// might continue in the data not read yet.
+func (scanner *Scanner) needMore(start int, end int) bool {
+	return end == len(scanner.buf) && !scanner.eof && end-start <= scanner.MaxUriSize
+}
+
+func (scanner *Scanner) accept(uri []byte, view *UriView) bool {
+	if view.DoubleSlash.Len() > 0 {
+		return view.Host.Len() > 0
+	}
+	if view.Path.Len() == 0 && view.Query.Len() == 0 {
+		return false
+	}
+	scheme := view.Scheme.Bytes(uri)
+	for _, opaque := range scanner.OpaqueSchemes {
+		if bytes.EqualFold(scheme, []byte(opaque)) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanUriChars returns the length of the bytes at the start of data which
+// can be in a URI.
+func scanUriChars(data []byte) int {
+	n := 0
+	for n < len(data) && (hasClass(data[n], classUnreserved|classSubDelims|classGenDelims) || data[n] == '%') {
+		n++
+	}
+	return n
+}
+
+// trimUriInText returns the length of uri without the bytes at its end
+// which are more likely to be a part of the text.
+func trimUriInText(uri []byte, schemeEnd int) int {
+	end := len(uri)
+	for end > schemeEnd+1 {
+		switch uri[end-1] {
+		case '.', ',', ';', ':', '!', '?', '\'':
+			end--
+			continue
+		case ')':
+			if bytes.Count(uri[:end], []byte("(")) < bytes.Count(uri[:end], []byte(")")) {
+				end--
+				continue
+			}
+		}
+		break
+	}
+	return end
+}
+
+// fill drops the data already scanned and reads more.
+func (scanner *Scanner) fill() bool {
+	// Keep the byte before pos to know whether a scheme starts at pos.
+	keep := scanner.pos - 1
+	if keep > 0 {
+		scanner.buf = append(scanner.buf[:0], scanner.buf[keep:]...)
+		scanner.offset += int64(keep)
+		scanner.pos -= keep
+	}
+
+	if cap(scanner.buf)-len(scanner.buf) < scannerReadSize {
+		buf := make([]byte, len(scanner.buf), 2*cap(scanner.buf)+scannerReadSize)
+		copy(buf, scanner.buf)
+		scanner.buf = buf
+	}
+	for i := 0; i < 100; i++ {
+		n, err := scanner.reader.Read(scanner.buf[len(scanner.buf):cap(scanner.buf)])
+		scanner.buf = scanner.buf[:len(scanner.buf)+n]
+		if err != nil {
+			scanner.eof = true
+			if !errors.Is(err, io.EOF) {
+				scanner.err = err
+			}
+			return true
+		}
+		if n > 0 {
+			return true
+		}
+	}
+	scanner.err = io.ErrNoProgress
+	return false
+}
@@ -0,0 +1,155 @@
+package urip
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScanner(t *testing.T) {
+	type TestCase struct {
+		testName       string
+		text           string
+		expectedUris   []string
+		expectedStarts []int64
+	}
+
+	tests := []TestCase{
+		{
+			testName:       "text: \"\"",
+			text:           "",
+			expectedUris:   []string{},
+			expectedStarts: []int64{},
+		},
+		{
+			testName:       "log line",
+			text:           "2024-01-01T00:00:00Z GET https://example.com/path?key=value 200",
+			expectedUris:   []string{"https://example.com/path?key=value"},
+			expectedStarts: []int64{25},
+		},
+		{
+			testName:       "multiple uris",
+			text:           "see http://a.example and ftp://user@b.example:21/file",
+			expectedUris:   []string{"http://a.example", "ftp://user@b.example:21/file"},
+			expectedStarts: []int64{4, 25},
+		},
+		{
+			testName:       "trailing punctuation",
+			text:           "Go to http://example.com/path. Or http://example.com/?q=1, please!",
+			expectedUris:   []string{"http://example.com/path", "http://example.com/?q=1"},
+			expectedStarts: []int64{6, 34},
+		},
+		{
+			testName:       "enclosing parentheses",
+			text:           "(see http://example.com/wiki/Foo_(bar)) and (http://example.com/)",
+			expectedUris:   []string{"http://example.com/wiki/Foo_(bar)", "http://example.com/"},
+			expectedStarts: []int64{5, 45},
+		},
+		{
+			testName:       "angle brackets",
+			text:           "<http://example.com/a> <URL:http://example.com/b>",
+			expectedUris:   []string{"http://example.com/a", "http://example.com/b"},
+			expectedStarts: []int64{1, 28},
+		},
+		{
+			testName:       "double-quotes",
+			text:           "href=\"http://example.com/\"",
+			expectedUris:   []string{"http://example.com/"},
+			expectedStarts: []int64{6},
+		},
+		{
+			testName:       "opaque schemes",
+			text:           "mail mailto:user@example.com or call tel:+1-201-555-0123.",
+			expectedUris:   []string{"mailto:user@example.com", "tel:+1-201-555-0123"},
+			expectedStarts: []int64{5, 37},
+		},
+		{
+			testName:       "not uris",
+			text:           "error: timeout key:value http:// x:",
+			expectedUris:   []string{},
+			expectedStarts: []int64{},
+		},
+		{
+			testName:       "scheme in a word",
+			text:           "xhttp://example.com",
+			expectedUris:   []string{"xhttp://example.com"},
+			expectedStarts: []int64{0},
+		},
+		{
+			testName:       "uri after colon",
+			text:           "url:http://example.com",
+			expectedUris:   []string{"http://example.com"},
+			expectedStarts: []int64{4},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			// OneByteReader makes every URI cross the reads.
+			for _, reader := range []string{"reader", "one byte reader"} {
+				var scanner *Scanner
+				if reader == "reader" {
+					scanner = NewScanner(strings.NewReader(testCase.text))
+				} else {
+					scanner = NewScanner(iotest.OneByteReader(strings.NewReader(testCase.text)))
+				}
+				uris := []string{}
+				starts := []int64{}
+				for scanner.Scan() {
+					match := scanner.Match()
+					uris = append(uris, string(match.Uri))
+					starts = append(starts, match.Start)
+					equals(testCase.testName+"(End)", t, match.Start+int64(len(match.Uri)), match.End)
+					equals(testCase.testName+"(Text)", t, testCase.text[match.Start:match.End], string(match.Uri))
+				}
+				if scanner.Err() != nil {
+					t.Errorf("%s(%s): %v", testCase.testName, reader, scanner.Err())
+				}
+				equals(testCase.testName+"("+reader+")", t, fmt.Sprint(testCase.expectedUris), fmt.Sprint(uris))
+				equals(testCase.testName+"("+reader+")", t, fmt.Sprint(testCase.expectedStarts), fmt.Sprint(starts))
+			}
+		})
+	}
+}
+
+func TestScannerMaxUriSize(t *testing.T) {
+	text := "http://example.com/" + strings.Repeat("a", 100) + " http://example.org/"
+	scanner := NewScanner(iotest.OneByteReader(strings.NewReader(text)))
+	scanner.MaxUriSize = 30
+	uris := []string{}
+	for scanner.Scan() {
+		uris = append(uris, string(scanner.Match().Uri))
+	}
+	expected := []string{"http://example.com/aaaaaaaaaaa", "http://example.org/"}
+	equals("MaxUriSize", t, fmt.Sprint(expected), fmt.Sprint(uris))
+
+	// The URI in the rest of a truncated URI is not found.
+	text = "http://example.com/?" + strings.Repeat("a", 100) + "&next=https://evil.example/x http://example.org/"
+	for _, scanner := range []*Scanner{
+		NewScanner(strings.NewReader(text)),
+		NewScanner(iotest.OneByteReader(strings.NewReader(text))),
+	} {
+		scanner.MaxUriSize = 30
+		uris := []string{}
+		truncated := []bool{}
+		for scanner.Scan() {
+			uris = append(uris, string(scanner.Match().Uri))
+			truncated = append(truncated, scanner.Match().Truncated)
+		}
+		expected := []string{"http://example.com/?aaaaaaaaaa", "http://example.org/"}
+		equals("MaxUriSize", t, fmt.Sprint(expected), fmt.Sprint(uris))
+		equals("Truncated", t, fmt.Sprint([]bool{true, false}), fmt.Sprint(truncated))
+	}
+}
+
+func TestScannerErr(t *testing.T) {
+	reader := iotest.TimeoutReader(strings.NewReader("http://example.com/ " + strings.Repeat("x", 5000)))
+	scanner := NewScanner(reader)
+	uris := []string{}
+	for scanner.Scan() {
+		uris = append(uris, string(scanner.Match().Uri))
+	}
+	equals("Uris", t, fmt.Sprint([]string{"http://example.com/"}), fmt.Sprint(uris))
+	equals("Err", t, fmt.Sprint(iotest.ErrTimeout), fmt.Sprint(scanner.Err()))
+}
@@ -0,0 +1,373 @@
+package urip
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errSipSchemeNotFound     = errors.New("sip or sips scheme not found.")
+	errSipUserNotFound       = errors.New("user not found.")
+	errSipPasswordNotFound   = errors.New("password not found.")
+	errSipHostNotFound       = errors.New("host not found.")
+	errSipPortNotFound       = errors.New("port not found.")
+	errSipParameterNotFound  = errors.New("uri-parameter not found.")
+	errSipParameterDuplicate = errors.New("uri-parameter appears more than once.")
+	errSipParamValueNotFound = errors.New("value of uri-parameter not found.")
+	errSipHeaderNotFound     = errors.New("header not found.")
+)
+
+// SipUri is a SIP or SIPS URI.
+type SipUri struct {
+	Secure   bool   // true if the scheme is sips
+	User     string // decoded, "" if userinfo is omitted
+	Password string // decoded
+	Host     string // hostname, IPv4address or IPv6reference with "[" and "]"
+	Port     string // "" if port is omitted
+	// Params are the uri-parameters. The names are in lowercase and the
+	// values are decoded.
+	Params []SipParam
+	// Headers are the decoded headers.
+	Headers []SipHeader
+}
+
+type SipParam struct {
+	Name  string
+	Value string // "" if the parameter does not have "=" pvalue, such as lr
+}
+
+type SipHeader struct {
+	Name  string
+	Value string
+}
+
+// ParseSipUri parses a SIP or SIPS URI.
+//
+// RFC3261 - 25.1 Basic Rules
+//
+//	SIP-URI          =  "sip:" [ userinfo ] hostport
+//	                    uri-parameters [ headers ]
+//	SIPS-URI         =  "sips:" [ userinfo ] hostport
+//	                    uri-parameters [ headers ]
+//	userinfo         =  ( user / telephone-subscriber ) [ ":" password ] "@"
+//	user             =  1*( unreserved / escaped / user-unreserved )
+//	password         =  *( unreserved / escaped /
+//	                    "&" / "=" / "+" / "$" / "," )
+//	hostport         =  host [ ":" port ]
+//	host             =  hostname / IPv4address / IPv6reference
+//	uri-parameters   =  *( ";" uri-parameter)
+//	uri-parameter    =  transport-param / user-param / method-param
+//	                    / ttl-param / maddr-param / lr-param / other-param
+//	other-param      =  pname [ "=" pvalue ]
+//	headers          =  "?" header *( "&" header )
+//	header           =  hname "=" hvalue
+//
+// NOTE
+// Parse is not used, since user may contain ";", "?" and "/", and the
+// uri-parameters are in path. "@" is not allowed unescaped other than at the
+// end of userinfo, so the first "@" ends userinfo.
+func ParseSipUri(data []byte) (*SipUri, error) {
+	scheme, rest, found := strings.Cut(string(data), ":")
+	if !found {
+		return nil, errSipSchemeNotFound
+	}
+	sip := &SipUri{}
+	switch strings.ToLower(scheme) {
+	case "sip":
+	case "sips":
+		sip.Secure = true
+	default:
+		return nil, errSipSchemeNotFound
+	}
+
+	// [ userinfo ]
+	if userinfo, hostport, found := strings.Cut(rest, "@"); found {
+		user, password, _ := strings.Cut(userinfo, ":")
+		if user == "" || !isSipChars(user, isSipUserChar) {
+			return nil, errSipUserNotFound
+		}
+		if !isSipChars(password, isSipPasswordChar) {
+			return nil, errSipPasswordNotFound
+		}
+		decodedUser, _ := decodePctEncoded([]byte(user))
+		decodedPassword, _ := decodePctEncoded([]byte(password))
+		sip.User = string(decodedUser)
+		sip.Password = string(decodedPassword)
+		rest = hostport
+	}
+
+	// hostport
+	rest, headers, hasHeaders := strings.Cut(rest, "?")
+	parameters := strings.Split(rest, ";")
+	hostport := parameters[0]
+	sip.Host = hostport
+	if strings.HasPrefix(hostport, "[") {
+		if end := strings.IndexByte(hostport, ']'); end >= 0 {
+			sip.Host = hostport[:end+1]
+			if port := hostport[end+1:]; port != "" {
+				if port[0] != ':' {
+					return nil, errSipHostNotFound
+				}
+				sip.Port = port[1:]
+				if !isDigits(sip.Port) {
+					return nil, errSipPortNotFound
+				}
+			}
+		}
+	} else if host, port, found := strings.Cut(hostport, ":"); found {
+		sip.Host = host
+		sip.Port = port
+		if !isDigits(sip.Port) {
+			return nil, errSipPortNotFound
+		}
+	}
+	if !isSipHost(sip.Host) {
+		return nil, errSipHostNotFound
+	}
+
+	// uri-parameters
+	seen := map[string]bool{}
+	for _, parameter := range parameters[1:] {
+		name, value, hasValue := strings.Cut(parameter, "=")
+		if name == "" || !isSipChars(name, isSipParamchar) || !isSipChars(value, isSipParamchar) || (hasValue && value == "") {
+			return nil, errSipParameterNotFound
+		}
+		decodedName, _ := decodePctEncoded([]byte(name))
+		decodedValue, _ := decodePctEncoded([]byte(value))
+		param := SipParam{Name: strings.ToLower(string(decodedName)), Value: string(decodedValue)}
+		if seen[param.Name] {
+			return nil, errSipParameterDuplicate
+		}
+		seen[param.Name] = true
+		if !isSipParamValue(param) {
+			return nil, errSipParamValueNotFound
+		}
+		sip.Params = append(sip.Params, param)
+	}
+
+	// [ headers ]
+	if hasHeaders {
+		for _, header := range strings.Split(headers, "&") {
+			name, value, found := strings.Cut(header, "=")
+			if !found || name == "" || !isSipChars(name, isSipHnvChar) || !isSipChars(value, isSipHnvChar) {
+				return nil, errSipHeaderNotFound
+			}
+			decodedName, _ := decodePctEncoded([]byte(name))
+			decodedValue, _ := decodePctEncoded([]byte(value))
+			sip.Headers = append(sip.Headers, SipHeader{Name: string(decodedName), Value: string(decodedValue)})
+		}
+	}
+	return sip, nil
+}
+
+// Param returns the value of the uri-parameter, and whether it is found.
+func (sip *SipUri) Param(name string) (string, bool) {
+	for _, param := range sip.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value, true
+		}
+	}
+	return "", false
+}
+
+// Lr reports whether the lr parameter is found.
+func (sip *SipUri) Lr() bool {
+	_, found := sip.Param("lr")
+	return found
+}
+
+// Equivalent reports whether sip and other are equivalent as
+// RFC3261 - 19.1.4 URI Comparison says.
+//
+//   - A SIP and a SIPS URI are never equivalent.
+//   - userinfo is compared case-sensitively, and host case-insensitively.
+//   - A component omitted is not equivalent to the one with its default value,
+//     such as port 5060.
+//   - The user, ttl, method, maddr and transport parameters must be in both
+//     or neither, and match. The other uri-parameters in only one are ignored.
+//   - Every header must be in both, and match.
+//
+// The pct-encodeds are compared decoded.
+func (sip *SipUri) Equivalent(other *SipUri) bool {
+	if sip.Secure != other.Secure ||
+		sip.User != other.User ||
+		sip.Password != other.Password ||
+		!strings.EqualFold(sip.Host, other.Host) ||
+		sip.Port != other.Port {
+		return false
+	}
+	for _, name := range []string{"user", "ttl", "method", "maddr", "transport"} {
+		value, found := sip.Param(name)
+		otherValue, otherFound := other.Param(name)
+		if found != otherFound || !strings.EqualFold(value, otherValue) {
+			return false
+		}
+	}
+	for _, param := range sip.Params {
+		value, found := other.Param(param.Name)
+		if found && !strings.EqualFold(param.Value, value) {
+			return false
+		}
+	}
+	if len(sip.Headers) != len(other.Headers) {
+		return false
+	}
+	for _, header := range sip.Headers {
+		matched := false
+		for _, otherHeader := range other.Headers {
+			if strings.EqualFold(header.Name, otherHeader.Name) && header.Value == otherHeader.Value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (sip *SipUri) String() string {
+	str := "sip:"
+	if sip.Secure {
+		str = "sips:"
+	}
+	if sip.User != "" {
+		str += string(encodePctEncoded([]byte(sip.User), isSipUserChar))
+		if sip.Password != "" {
+			str += ":" + string(encodePctEncoded([]byte(sip.Password), isSipPasswordChar))
+		}
+		str += "@"
+	}
+	str += sip.Host
+	if sip.Port != "" {
+		str += ":" + sip.Port
+	}
+	for _, param := range sip.Params {
+		str += ";" + string(encodePctEncoded([]byte(param.Name), isSipParamchar))
+		if param.Value != "" {
+			str += "=" + string(encodePctEncoded([]byte(param.Value), isSipParamchar))
+		}
+	}
+	for i, header := range sip.Headers {
+		if i == 0 {
+			str += "?"
+		} else {
+			str += "&"
+		}
+		str += string(encodePctEncoded([]byte(header.Name), isSipHnvChar)) + "=" +
+			string(encodePctEncoded([]byte(header.Value), isSipHnvChar))
+	}
+	return str
+}
+
+// RFC3261 - 25.1 Basic Rules
+//
+//	transport-param   =  "transport="
+//	                     ( "udp" / "tcp" / "sctp" / "tls"
+//	                     / other-transport)
+//	other-transport   =  token
+//	user-param        =  "user=" ( "phone" / "ip" / other-user)
+//	other-user        =  token
+//	method-param      =  "method=" Method
+//	ttl-param         =  "ttl=" ttl
+//	ttl               =  1*3DIGIT ; 0 to 255
+//	maddr-param       =  "maddr=" host
+//	lr-param          =  "lr"
+//
+
+func isSipParamValue(param SipParam) bool {
+	switch param.Name {
+	case "transport", "user", "method":
+		return isSipToken(param.Value)
+	case "ttl":
+		ttl, err := strconv.Atoi(param.Value)
+		return isDigits(param.Value) && len(param.Value) <= 3 && err == nil && ttl <= 255
+	case "maddr":
+		return isSipHost(param.Value)
+	case "lr":
+		return param.Value == ""
+	}
+	return true
+}
+
+// RFC3261 - 25.1 Basic Rules
+//
+//	host             =  hostname / IPv4address / IPv6reference
+//	IPv6reference    =  "[" IPv6address "]"
+//
+
+func isSipHost(host string) bool {
+	if len(host) > 2 && host[0] == '[' && host[len(host)-1] == ']' {
+		return isIpV6Address([]byte(host[1 : len(host)-1]))
+	}
+	if len(host) > 0 && scanIpV4Address([]byte(host), 0) == len(host) {
+		return true
+	}
+	return isDomainName(host)
+}
+
+// RFC3261 - 25.1 Basic Rules
+//
+//	token       =  1*(alphanum / "-" / "." / "!" / "%" / "*"
+//	               / "_" / "+" / "`" / "'" / "~" )
+//
+
+func isSipToken(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if !isAlpha(str[i]) && !isDigit(str[i]) && strings.IndexByte("-.!%*_+`'~", str[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSipChars reports whether each octet of str is allowed or a part of
+// escaped.
+func isSipChars(str string, allowed func(c byte) bool) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] == '%' {
+			if !isPctEncodedNoCase([]byte(str), i) {
+				return false
+			}
+			i += 2
+			continue
+		}
+		if !allowed(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RFC3261 - 25.1 Basic Rules
+//
+//	user-unreserved  =  "&" / "=" / "+" / "$" / "," / ";" / "?" / "/"
+//	param-unreserved =  "[" / "]" / "/" / ":" / "&" / "+" / "$"
+//	hnv-unreserved   =  "[" / "]" / "/" / "?" / ":" / "+" / "$"
+//
+// The unreserved of RFC3261 also has the mark of RFC2396.
+
+func isSipUnreserved(c byte) bool {
+	return isUnreserved(c) || strings.IndexByte("!*'()", c) >= 0
+}
+
+func isSipUserChar(c byte) bool {
+	return isSipUnreserved(c) || strings.IndexByte("&=+$,;?/", c) >= 0
+}
+
+func isSipPasswordChar(c byte) bool {
+	return isSipUnreserved(c) || strings.IndexByte("&=+$,", c) >= 0
+}
+
+func isSipParamchar(c byte) bool {
+	return isSipUnreserved(c) || strings.IndexByte("[]/:&+$", c) >= 0
+}
+
+func isSipHnvChar(c byte) bool {
+	return isSipUnreserved(c) || strings.IndexByte("[]/?:+$", c) >= 0
+}
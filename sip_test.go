@@ -0,0 +1,206 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseSipUri(t *testing.T) {
+	type TestCase struct {
+		testName    string
+		data        []byte
+		expectedSip SipUri
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		// RFC3261 - 19.1.3 Example SIP and SIPS URIs
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com\")",
+			data:        []byte("sip:alice@atlanta.com"),
+			expectedSip: SipUri{User: "alice", Host: "atlanta.com"},
+		},
+		{
+			testName: "data: []byte(\"sip:alice:secretword@atlanta.com;transport=tcp\")",
+			data:     []byte("sip:alice:secretword@atlanta.com;transport=tcp"),
+			expectedSip: SipUri{
+				User: "alice", Password: "secretword", Host: "atlanta.com",
+				Params: []SipParam{{Name: "transport", Value: "tcp"}},
+			},
+		},
+		{
+			testName: "data: []byte(\"sips:alice@atlanta.com?subject=project%20x&priority=urgent\")",
+			data:     []byte("sips:alice@atlanta.com?subject=project%20x&priority=urgent"),
+			expectedSip: SipUri{
+				Secure: true, User: "alice", Host: "atlanta.com",
+				Headers: []SipHeader{{Name: "subject", Value: "project x"}, {Name: "priority", Value: "urgent"}},
+			},
+		},
+		{
+			testName: "data: []byte(\"sip:+1-212-555-1212:1234@gateway.com;user=phone\")",
+			data:     []byte("sip:+1-212-555-1212:1234@gateway.com;user=phone"),
+			expectedSip: SipUri{
+				User: "+1-212-555-1212", Password: "1234", Host: "gateway.com",
+				Params: []SipParam{{Name: "user", Value: "phone"}},
+			},
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@192.0.2.4\")",
+			data:        []byte("sip:alice@192.0.2.4"),
+			expectedSip: SipUri{User: "alice", Host: "192.0.2.4"},
+		},
+		{
+			testName: "data: []byte(\"sip:atlanta.com;method=REGISTER?to=alice%40atlanta.com\")",
+			data:     []byte("sip:atlanta.com;method=REGISTER?to=alice%40atlanta.com"),
+			expectedSip: SipUri{
+				Host:    "atlanta.com",
+				Params:  []SipParam{{Name: "method", Value: "REGISTER"}},
+				Headers: []SipHeader{{Name: "to", Value: "alice@atlanta.com"}},
+			},
+		},
+		{
+			testName:    "data: []byte(\"sip:alice;day=tuesday@atlanta.com\")",
+			data:        []byte("sip:alice;day=tuesday@atlanta.com"),
+			expectedSip: SipUri{User: "alice;day=tuesday", Host: "atlanta.com"},
+		},
+		{
+			testName: "data: []byte(\"sip:[2001:db8::10]:5070;lr;ttl=255;maddr=239.255.255.1\")",
+			data:     []byte("sip:[2001:db8::10]:5070;lr;ttl=255;maddr=239.255.255.1"),
+			expectedSip: SipUri{
+				Host: "[2001:db8::10]", Port: "5070",
+				Params: []SipParam{{Name: "lr"}, {Name: "ttl", Value: "255"}, {Name: "maddr", Value: "239.255.255.1"}},
+			},
+		},
+		{
+			testName:    "data: []byte(\"http://atlanta.com\")",
+			data:        []byte("http://atlanta.com"),
+			expectedErr: errSipSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:@atlanta.com\")",
+			data:        []byte("sip:@atlanta.com"),
+			expectedErr: errSipUserNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice:pass;word@atlanta.com\")",
+			data:        []byte("sip:alice:pass;word@atlanta.com"),
+			expectedErr: errSipPasswordNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@\")",
+			data:        []byte("sip:alice@"),
+			expectedErr: errSipHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@[2001:db8::g]\")",
+			data:        []byte("sip:alice@[2001:db8::g]"),
+			expectedErr: errSipHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com:50a\")",
+			data:        []byte("sip:alice@atlanta.com:50a"),
+			expectedErr: errSipPortNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com;ttl=256\")",
+			data:        []byte("sip:alice@atlanta.com;ttl=256"),
+			expectedErr: errSipParamValueNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com;transport=t%20p\")",
+			data:        []byte("sip:alice@atlanta.com;transport=t%20p"),
+			expectedErr: errSipParamValueNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com;lr;LR\")",
+			data:        []byte("sip:alice@atlanta.com;lr;LR"),
+			expectedErr: errSipParameterDuplicate,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com;a b\")",
+			data:        []byte("sip:alice@atlanta.com;a b"),
+			expectedErr: errSipParameterNotFound,
+		},
+		{
+			testName:    "data: []byte(\"sip:alice@atlanta.com?subject\")",
+			data:        []byte("sip:alice@atlanta.com?subject"),
+			expectedErr: errSipHeaderNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			sip, err := ParseSipUri(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(Secure)", t, testCase.expectedSip.Secure, sip.Secure)
+			equals(testCase.testName+"(User)", t, testCase.expectedSip.User, sip.User)
+			equals(testCase.testName+"(Password)", t, testCase.expectedSip.Password, sip.Password)
+			equals(testCase.testName+"(Host)", t, testCase.expectedSip.Host, sip.Host)
+			equals(testCase.testName+"(Port)", t, testCase.expectedSip.Port, sip.Port)
+			equals(testCase.testName+"(Params)", t, fmt.Sprint(testCase.expectedSip.Params), fmt.Sprint(sip.Params))
+			equals(testCase.testName+"(Headers)", t, fmt.Sprint(testCase.expectedSip.Headers), fmt.Sprint(sip.Headers))
+
+			parsed, err := ParseSipUri([]byte(sip.String()))
+			if err != nil {
+				t.Errorf("Failed to parse %v: %v", sip.String(), err.Error())
+				return
+			}
+			equals(testCase.testName+"(String)", t, true, sip.Equivalent(parsed))
+		})
+	}
+}
+
+func TestSipUriEquivalent(t *testing.T) {
+	type TestCase struct {
+		data1    string
+		data2    string
+		expected bool
+	}
+
+	// RFC3261 - 19.1.4 URI Comparison
+	tests := []TestCase{
+		{data1: "sip:%61lice@atlanta.com;transport=TCP", data2: "sip:alice@AtLanTa.CoM;Transport=tcp", expected: true},
+		{data1: "sip:carol@chicago.com", data2: "sip:carol@chicago.com;newparam=5", expected: true},
+		{data1: "sip:carol@chicago.com", data2: "sip:carol@chicago.com;security=on", expected: true},
+		{data1: "sip:carol@chicago.com;newparam=5", data2: "sip:carol@chicago.com;security=on", expected: true},
+		{
+			data1:    "sip:biloxi.com;transport=tcp;method=REGISTER?to=sip:bob%40biloxi.com",
+			data2:    "sip:biloxi.com;method=REGISTER;transport=tcp?to=sip:bob%40biloxi.com",
+			expected: true,
+		},
+		{
+			data1:    "sip:alice@atlanta.com?subject=project%20x&priority=urgent",
+			data2:    "sip:alice@atlanta.com?priority=urgent&subject=project%20x",
+			expected: true,
+		},
+		{data1: "SIP:ALICE@AtLanTa.CoM;Transport=udp", data2: "sip:alice@AtLanTa.CoM;Transport=UDP", expected: false},
+		{data1: "sip:bob@biloxi.com", data2: "sip:bob@biloxi.com:5060", expected: false},
+		{data1: "sip:bob@biloxi.com", data2: "sip:bob@biloxi.com;transport=udp", expected: false},
+		{data1: "sip:bob@biloxi.com", data2: "sip:bob@biloxi.com:6000;transport=tcp", expected: false},
+		{data1: "sip:carol@chicago.com", data2: "sip:carol@chicago.com?Subject=next%20meeting", expected: false},
+		{data1: "sip:bob@phone21.boxesbybob.com", data2: "sip:bob@192.0.2.4", expected: false},
+		{data1: "sip:carol@chicago.com;security=on", data2: "sip:carol@chicago.com;security=off", expected: false},
+		{data1: "sip:alice@atlanta.com", data2: "sips:alice@atlanta.com", expected: false},
+	}
+
+	for _, testCase := range tests {
+		testName := fmt.Sprintf("%v, %v", testCase.data1, testCase.data2)
+		t.Run(testName, func(t *testing.T) {
+			sip1, err := ParseSipUri([]byte(testCase.data1))
+			if err != nil {
+				t.Errorf("Failed to parse sip: %v", err.Error())
+				return
+			}
+			sip2, err := ParseSipUri([]byte(testCase.data2))
+			if err != nil {
+				t.Errorf("Failed to parse sip: %v", err.Error())
+				return
+			}
+			equals(testName, t, testCase.expected, sip1.Equivalent(sip2))
+			equals(testName+"(reversed)", t, testCase.expected, sip2.Equivalent(sip1))
+		})
+	}
+}
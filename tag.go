@@ -0,0 +1,139 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	errTagSchemeNotFound     = errors.New("tag scheme not found.")
+	errTaggingEntityNotFound = errors.New("taggingEntity not found.")
+	errAuthorityNameNotFound = errors.New("authorityName of tag URI not found.")
+	errTagDateNotFound       = errors.New("date of tag URI not found.")
+)
+
+// Tag is a tag URI. Specific and Fragment are not decoded.
+type Tag struct {
+	AuthorityName string // DNSname or emailAddress
+	Date          string // as written, such as "2001", "2001-09" or "2001-09-15"
+	Specific      string
+	Fragment      string // "" if it is omitted
+}
+
+// ParseTag parses a tag URI.
+//
+// RFC4151 - 2.1. Tag Syntax
+//
+//	tagURI = "tag:" taggingEntity ":" specific [ "#" fragment ]
+//	taggingEntity = authorityName "," date
+//	authorityName = DNSname / emailAddress
+//	date = year ["-" month ["-" day]]
+//	year = 4DIGIT
+//	month = 2DIGIT
+//	day = 2DIGIT
+//	DNSname = DNScomp *( "."  DNScomp ) ; see RFC 1035 [3]
+//	DNScomp = alphaNum [*(alphaNum /"-") alphaNum]
+//	emailAddress = 1*(alphaNum /"-"/"."/"_") "@" DNSname
+//	alphaNum = DIGIT / ALPHA
+//	specific = *( pchar / "/" / "?" ) ; pchar from RFC 3986
+//	fragment = *( pchar / "/" / "?" ) ; same as RFC 3986
+//
+// The date must be a valid date of the Gregorian calendar.
+//
+// NOTE
+// Parse takes "?" in specific as the start of query. So the query is joined
+// to specific.
+func ParseTag(data []byte) (*Tag, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("tag")) {
+		return nil, errTagSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 {
+		return nil, errTaggingEntityNotFound
+	}
+	entity, specific, found := strings.Cut(string(uri.Path), ":")
+	if !found {
+		return nil, errTaggingEntityNotFound
+	}
+	authorityName, date, found := strings.Cut(entity, ",")
+	if !found {
+		return nil, errTaggingEntityNotFound
+	}
+	if !isTagAuthorityName(authorityName) {
+		return nil, errAuthorityNameNotFound
+	}
+	if !isTagDate(date) {
+		return nil, errTagDateNotFound
+	}
+	if len(uri.Question) > 0 {
+		specific += "?" + string(uri.Query)
+	}
+	return &Tag{
+		AuthorityName: authorityName,
+		Date:          date,
+		Specific:      specific,
+		Fragment:      string(uri.Fragment),
+	}, nil
+}
+
+// Equivalent reports whether the tags are the same. As RFC4151 - 2.4.
+// Equality of tags says, the tags are compared character by character,
+// except for the scheme which is case insensitive.
+func (tag *Tag) Equivalent(other *Tag) bool {
+	return tag.String() == other.String()
+}
+
+func (tag *Tag) String() string {
+	str := "tag:" + tag.AuthorityName + "," + tag.Date + ":" + tag.Specific
+	if tag.Fragment != "" {
+		str += "#" + tag.Fragment
+	}
+	return str
+}
+
+func isTagAuthorityName(name string) bool {
+	if at := strings.LastIndexByte(name, '@'); at >= 0 {
+		local := name[:at]
+		if local == "" {
+			return false
+		}
+		for i := 0; i < len(local); i++ {
+			c := local[i]
+			if !isAlpha(c) && !isDigit(c) && c != '-' && c != '.' && c != '_' {
+				return false
+			}
+		}
+		name = name[at+1:]
+	}
+	for _, comp := range strings.Split(name, ".") {
+		if len(comp) == 0 || comp[0] == '-' || comp[len(comp)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(comp); i++ {
+			if !isAlpha(comp[i]) && !isDigit(comp[i]) && comp[i] != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isTagDate(date string) bool {
+	layouts := map[int]string{4: "2006", 7: "2006-01", 10: "2006-01-02"}
+	layout, found := layouts[len(date)]
+	if !found {
+		return false
+	}
+	for i := 0; i < len(date); i++ {
+		if (i == 4 || i == 7) != (date[i] == '-') || (date[i] != '-' && !isDigit(date[i])) {
+			return false
+		}
+	}
+	_, err := time.Parse(layout, date)
+	return err == nil
+}
@@ -0,0 +1,82 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	type TestCase struct {
+		data        string
+		expected    Tag
+		expectedErr error
+	}
+
+	// RFC4151 - 2.1. Tag Syntax (Examples)
+	tests := []TestCase{
+		{data: "tag:timothy@hpl.hp.com,2001:web/externalHome", expected: Tag{AuthorityName: "timothy@hpl.hp.com", Date: "2001", Specific: "web/externalHome"}},
+		{data: "tag:sandro@w3.org,2004-05:Sandro", expected: Tag{AuthorityName: "sandro@w3.org", Date: "2004-05", Specific: "Sandro"}},
+		{data: "tag:my-ids.com,2001-09-15:TimKindberg:presentations:UBath2004-05-19", expected: Tag{AuthorityName: "my-ids.com", Date: "2001-09-15", Specific: "TimKindberg:presentations:UBath2004-05-19"}},
+		{data: "tag:blogger.com,1999:blog-555", expected: Tag{AuthorityName: "blogger.com", Date: "1999", Specific: "blog-555"}},
+		{data: "tag:yaml.org,2002:int", expected: Tag{AuthorityName: "yaml.org", Date: "2002", Specific: "int"}},
+		{data: "tag:example.com,2000-02-29:a?b/c#frag", expected: Tag{AuthorityName: "example.com", Date: "2000-02-29", Specific: "a?b/c", Fragment: "frag"}},
+		{data: "tag:example.com,2000:", expected: Tag{AuthorityName: "example.com", Date: "2000"}},
+		{data: "urn:example.com,2000:a", expectedErr: errTagSchemeNotFound},
+		{data: "tag:example.com:a", expectedErr: errTaggingEntityNotFound},
+		{data: "tag:example.com,2000", expectedErr: errTaggingEntityNotFound},
+		{data: "tag://example.com,2000/a", expectedErr: errTaggingEntityNotFound},
+		{data: "tag:-example.com,2000:a", expectedErr: errAuthorityNameNotFound},
+		{data: "tag:example..com,2000:a", expectedErr: errAuthorityNameNotFound},
+		{data: "tag:@example.com,2000:a", expectedErr: errAuthorityNameNotFound},
+		{data: "tag:a+b@example.com,2000:a", expectedErr: errAuthorityNameNotFound},
+		{data: "tag:example.com,01:a", expectedErr: errTagDateNotFound},
+		{data: "tag:example.com,2001-13:a", expectedErr: errTagDateNotFound},
+		{data: "tag:example.com,2001-02-29:a", expectedErr: errTagDateNotFound},
+		{data: "tag:example.com,2001-1-01:a", expectedErr: errTagDateNotFound},
+		{data: "tag:example.com,+001:a", expectedErr: errTagDateNotFound},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			tag, err := ParseTag([]byte(testCase.data))
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.data, t, fmt.Sprintf("%+v", testCase.expected), fmt.Sprintf("%+v", *tag))
+			equals(testCase.data+"(String)", t, testCase.data, tag.String())
+		})
+	}
+}
+
+func TestTagEquivalent(t *testing.T) {
+	type TestCase struct {
+		data1    string
+		data2    string
+		expected bool
+	}
+
+	tests := []TestCase{
+		{data1: "tag:example.com,2000:a", data2: "TAG:example.com,2000:a", expected: true},
+		{data1: "tag:example.com,2000:a", data2: "tag:Example.com,2000:a", expected: false},
+		{data1: "tag:example.com,2000:a", data2: "tag:example.com,2000-01:a", expected: false},
+		{data1: "tag:example.com,2000:%41", data2: "tag:example.com,2000:A", expected: false},
+	}
+
+	for _, testCase := range tests {
+		testName := testCase.data1 + " " + testCase.data2
+		t.Run(testName, func(t *testing.T) {
+			tag1, err := ParseTag([]byte(testCase.data1))
+			if err != nil {
+				t.Errorf("Failed to parse data1: %v", err.Error())
+				return
+			}
+			tag2, err := ParseTag([]byte(testCase.data2))
+			if err != nil {
+				t.Errorf("Failed to parse data2: %v", err.Error())
+				return
+			}
+			equals(testName, t, testCase.expected, tag1.Equivalent(tag2))
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	errTelSchemeNotFound     = errors.New("tel scheme not found.")
+	errTelAuthority          = errors.New("tel URI cannot have authority.")
+	errTelQueryOrFragment    = errors.New("tel URI cannot have query or fragment.")
+	errTelNumberNotFound     = errors.New("global-number-digits or local-number-digits not found.")
+	errPhoneContextNotFound  = errors.New("phone-context of local-number not found.")
+	errPhoneContextForGlobal = errors.New("global-number cannot have phone-context.")
+	errExtensionNotFound     = errors.New("extension not found.")
+	errIsdnSubaddressInvalid = errors.New("isdn-subaddress not found.")
+	errDescriptorNotFound    = errors.New("descriptor of phone-context not found.")
+	errTelParameterNotFound  = errors.New("parameter not found.")
+	errTelParameterDuplicate = errors.New("parameter appears more than once.")
+)
+
+// Tel is a tel URI.
+type Tel struct {
+	// Number is the global-number-digits or the local-number-digits without
+	// visual separators, such as "+12015550123" or "7042". The hexadecimal
+	// digits are in uppercase.
+	Number string
+	// Extension is the extension without visual separators.
+	Extension string
+	// IsdnSubaddress is the decoded isdn-subaddress.
+	IsdnSubaddress string
+	// PhoneContext is the descriptor of local-number. A global-number-digits
+	// is without visual separators, and a domainname is in lowercase.
+	PhoneContext string
+	// Params are the parameters other than ext, isub and phone-context.
+	// The names are in lowercase and the values are decoded.
+	Params []TelParam
+}
+
+type TelParam struct {
+	Name  string
+	Value string // "" if the parameter does not have "=" pvalue
+}
+
+// ParseTel parses a tel URI.
+//
+// RFC3966 - 3. URI Syntax
+//
+//	telephone-uri        = "tel:" telephone-subscriber
+//	telephone-subscriber = global-number / local-number
+//	global-number        = global-number-digits *par
+//	local-number         = local-number-digits *par context *par
+//	par                  = parameter / extension / isdn-subaddress
+//	isdn-subaddress      = ";isub=" 1*uric
+//	extension            = ";ext=" 1*phonedigit
+//	context              = ";phone-context=" descriptor
+//	descriptor           = domainname / global-number-digits
+//	global-number-digits = "+" *phonedigit DIGIT *phonedigit
+//	local-number-digits  =
+//	   *phonedigit-hex (HEXDIG / "*" / "#")*phonedigit-hex
+//	parameter            = ";" pname ["=" pvalue ]
+//	pname                = 1*( alphanum / "-" )
+//	pvalue               = 1*paramchar
+//	phonedigit           = DIGIT / [ visual-separator ]
+//	phonedigit-hex       = HEXDIG / "*" / "#" / [ visual-separator ]
+//	visual-separator     = "-" / "." / "(" / ")"
+//
+// The order of the parameters is not validated. "#" in local-number-digits
+// must be pct-encoded as "%23", since it is the start of fragment in URI.
+func ParseTel(data []byte) (*Tel, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("tel")) {
+		return nil, errTelSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 {
+		return nil, errTelAuthority
+	}
+	if len(uri.Question) > 0 || len(uri.Sharp) > 0 {
+		return nil, errTelQueryOrFragment
+	}
+
+	pars := bytes.Split(uri.Path, []byte(";"))
+	number, err := decodePctEncoded(pars[0])
+	if err != nil {
+		return nil, err
+	}
+	tel := &Tel{}
+	tel.Number, err = telNumberDigits(string(number))
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for _, par := range pars[1:] {
+		name, value, hasValue := bytes.Cut(par, []byte("="))
+		if !isPname(name) {
+			return nil, errTelParameterNotFound
+		}
+		lowerName := strings.ToLower(string(name))
+		if found[lowerName] {
+			return nil, errTelParameterDuplicate
+		}
+		found[lowerName] = true
+		decoded, err := decodePctEncoded(value)
+		if err != nil {
+			return nil, err
+		}
+		if hasValue && len(decoded) == 0 {
+			return nil, errTelParameterNotFound
+		}
+
+		switch lowerName {
+		case "ext":
+			tel.Extension = stripVisualSeparators(string(decoded))
+			if !isDigits(tel.Extension) {
+				return nil, errExtensionNotFound
+			}
+		case "isub":
+			if len(decoded) == 0 {
+				return nil, errIsdnSubaddressInvalid
+			}
+			tel.IsdnSubaddress = string(decoded)
+		case "phone-context":
+			tel.PhoneContext, err = telDescriptor(string(decoded))
+			if err != nil {
+				return nil, err
+			}
+		default:
+			tel.Params = append(tel.Params, TelParam{Name: lowerName, Value: string(decoded)})
+		}
+	}
+
+	if tel.Global() && tel.PhoneContext != "" {
+		return nil, errPhoneContextForGlobal
+	}
+	if !tel.Global() && tel.PhoneContext == "" {
+		return nil, errPhoneContextNotFound
+	}
+	return tel, nil
+}
+
+// Global reports whether the number is a global-number.
+func (tel *Tel) Global() bool {
+	return strings.HasPrefix(tel.Number, "+")
+}
+
+// Equivalent reports whether tel and other are equal as RFC3966 - 4. URI
+// Comparisons says. Both must be global or local, and the numbers and
+// phone-contexts are compared without visual separators. The parameters are
+// compared regardless of their order, case-insensitively.
+func (tel *Tel) Equivalent(other *Tel) bool {
+	if tel.Number != other.Number ||
+		!strings.EqualFold(tel.PhoneContext, other.PhoneContext) ||
+		tel.Extension != other.Extension ||
+		!strings.EqualFold(tel.IsdnSubaddress, other.IsdnSubaddress) ||
+		len(tel.Params) != len(other.Params) {
+		return false
+	}
+	params := map[string]string{}
+	for _, param := range tel.Params {
+		params[param.Name] = param.Value
+	}
+	for _, param := range other.Params {
+		value, found := params[param.Name]
+		if !found || !strings.EqualFold(value, param.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// String encodes the tel URI. As RFC3966 - 3 recommends, the extension or the
+// isdn-subaddress is first, phone-context is next, and the other parameters
+// follow in lexicographical order.
+func (tel *Tel) String() string {
+	str := "tel:" + strings.ReplaceAll(tel.Number, "#", "%23")
+	if tel.Extension != "" {
+		str += ";ext=" + tel.Extension
+	}
+	if tel.IsdnSubaddress != "" {
+		str += ";isub=" + string(encodePctEncoded([]byte(tel.IsdnSubaddress), isParamchar))
+	}
+	if tel.PhoneContext != "" {
+		str += ";phone-context=" + tel.PhoneContext
+	}
+	params := append([]TelParam{}, tel.Params...)
+	sort.SliceStable(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	for _, param := range params {
+		str += ";" + param.Name
+		if param.Value != "" {
+			str += "=" + string(encodePctEncoded([]byte(param.Value), isParamchar))
+		}
+	}
+	return str
+}
+
+// telNumberDigits validates global-number-digits or local-number-digits,
+// and strips the visual separators.
+func telNumberDigits(number string) (string, error) {
+	global := strings.HasPrefix(number, "+")
+	digits := stripVisualSeparators(strings.ToUpper(strings.TrimPrefix(number, "+")))
+	if len(digits) == 0 {
+		return "", errTelNumberNotFound
+	}
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if global && !isDigit(c) {
+			return "", errTelNumberNotFound
+		}
+		if !global && !isHexDigNoCase(c) && c != '*' && c != '#' {
+			return "", errTelNumberNotFound
+		}
+	}
+	if global {
+		return "+" + digits, nil
+	}
+	return digits, nil
+}
+
+func telDescriptor(descriptor string) (string, error) {
+	if strings.HasPrefix(descriptor, "+") {
+		digits, err := telNumberDigits(descriptor)
+		if err != nil {
+			return "", errDescriptorNotFound
+		}
+		return digits, nil
+	}
+	if !isDomainName(descriptor) {
+		return "", errDescriptorNotFound
+	}
+	return strings.ToLower(descriptor), nil
+}
+
+// RFC3966 - 3. URI Syntax
+// RFC3261 - 25.1 Basic Rules (as hostname)
+//
+//	domainname           = *( domainlabel "." ) toplabel [ "." ]
+//	domainlabel          = alphanum
+//	                       / alphanum *( alphanum / "-" ) alphanum
+//	toplabel             = ALPHA / ALPHA *( alphanum / "-" ) alphanum
+//
+
+func isDomainName(name string) bool {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i, label := range labels {
+		if len(label) == 0 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		if i == len(labels)-1 && !isAlpha(label[0]) {
+			return false
+		}
+		for j := 0; j < len(label); j++ {
+			if !isAlpha(label[j]) && !isDigit(label[j]) && label[j] != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func stripVisualSeparators(digits string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune("-.()", r) {
+			return -1
+		}
+		return r
+	}, digits)
+}
+
+func isPname(name []byte) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, c := range name {
+		if !isAlpha(c) && !isDigit(c) && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// RFC3966 - 3. URI Syntax
+//
+//	paramchar            = param-unreserved / unreserved / pct-encoded
+//	param-unreserved     = "[" / "]" / "/" / ":" / "&" / "+" / "$"
+//
+// "[" and "]" are pct-encoded, since RFC3986 does not allow them in path.
+
+func isParamchar(c byte) bool {
+	return isUnreserved(c) || strings.IndexByte("/:&+$", c) >= 0
+}
@@ -0,0 +1,170 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseTel(t *testing.T) {
+	type TestCase struct {
+		testName    string
+		data        []byte
+		expectedTel Tel
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		// RFC3966 - 6. Examples
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-0123\")",
+			data:        []byte("tel:+1-201-555-0123"),
+			expectedTel: Tel{Number: "+12015550123"},
+		},
+		{
+			testName:    "data: []byte(\"tel:7042;phone-context=example.com\")",
+			data:        []byte("tel:7042;phone-context=example.com"),
+			expectedTel: Tel{Number: "7042", PhoneContext: "example.com"},
+		},
+		{
+			testName:    "data: []byte(\"tel:863-1234;phone-context=+1-914-555\")",
+			data:        []byte("tel:863-1234;phone-context=+1-914-555"),
+			expectedTel: Tel{Number: "8631234", PhoneContext: "+1914555"},
+		},
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-0123;ext=1-234;isub=abc%3B1\")",
+			data:        []byte("tel:+1-201-555-0123;ext=1-234;isub=abc%3B1"),
+			expectedTel: Tel{Number: "+12015550123", Extension: "1234", IsdnSubaddress: "abc;1"},
+		},
+		{
+			testName: "data: []byte(\"tel:*1a%23;phone-context=Example.COM;Foo=Bar;baz\")",
+			data:     []byte("tel:*1a%23;phone-context=Example.COM;Foo=Bar;baz"),
+			expectedTel: Tel{
+				Number:       "*1A#",
+				PhoneContext: "example.com",
+				Params:       []TelParam{{Name: "foo", Value: "Bar"}, {Name: "baz"}},
+			},
+		},
+		{
+			testName:    "data: []byte(\"sip:+1-201-555-0123\")",
+			data:        []byte("sip:+1-201-555-0123"),
+			expectedErr: errTelSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"tel://+1-201-555-0123\")",
+			data:        []byte("tel://+1-201-555-0123"),
+			expectedErr: errTelAuthority,
+		},
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-0123?a\")",
+			data:        []byte("tel:+1-201-555-0123?a"),
+			expectedErr: errTelQueryOrFragment,
+		},
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-012a\")",
+			data:        []byte("tel:+1-201-555-012a"),
+			expectedErr: errTelNumberNotFound,
+		},
+		{
+			testName:    "data: []byte(\"tel:+--\")",
+			data:        []byte("tel:+--"),
+			expectedErr: errTelNumberNotFound,
+		},
+		{
+			testName:    "data: []byte(\"tel:7042\")",
+			data:        []byte("tel:7042"),
+			expectedErr: errPhoneContextNotFound,
+		},
+		{
+			testName:    "data: []byte(\"tel:+7042;phone-context=example.com\")",
+			data:        []byte("tel:+7042;phone-context=example.com"),
+			expectedErr: errPhoneContextForGlobal,
+		},
+		{
+			testName:    "data: []byte(\"tel:7042;phone-context=-example.com\")",
+			data:        []byte("tel:7042;phone-context=-example.com"),
+			expectedErr: errDescriptorNotFound,
+		},
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-0123;ext=12a\")",
+			data:        []byte("tel:+1-201-555-0123;ext=12a"),
+			expectedErr: errExtensionNotFound,
+		},
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-0123;ext=1;EXT=2\")",
+			data:        []byte("tel:+1-201-555-0123;ext=1;EXT=2"),
+			expectedErr: errTelParameterDuplicate,
+		},
+		{
+			testName:    "data: []byte(\"tel:+1-201-555-0123;a_b=1\")",
+			data:        []byte("tel:+1-201-555-0123;a_b=1"),
+			expectedErr: errTelParameterNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			tel, err := ParseTel(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(Number)", t, testCase.expectedTel.Number, tel.Number)
+			equals(testCase.testName+"(Extension)", t, testCase.expectedTel.Extension, tel.Extension)
+			equals(testCase.testName+"(IsdnSubaddress)", t, testCase.expectedTel.IsdnSubaddress, tel.IsdnSubaddress)
+			equals(testCase.testName+"(PhoneContext)", t, testCase.expectedTel.PhoneContext, tel.PhoneContext)
+			sliceHasSameElem(testCase.testName+"(Params)", t, testCase.expectedTel.Params, tel.Params)
+
+			parsed, err := ParseTel([]byte(tel.String()))
+			if err != nil {
+				t.Errorf("Failed to parse %v: %v", tel.String(), err.Error())
+				return
+			}
+			equals(testCase.testName+"(String)", t, true, tel.Equivalent(parsed))
+		})
+	}
+}
+
+func TestTelEquivalent(t *testing.T) {
+	type TestCase struct {
+		data1    string
+		data2    string
+		expected bool
+	}
+
+	tests := []TestCase{
+		{data1: "tel:+1-201-555-0123", data2: "tel:+1(201)555.0123", expected: true},
+		{data1: "tel:+1-201-555-0123", data2: "tel:+1-201-555-0124", expected: false},
+		{data1: "tel:+12015550123", data2: "tel:12015550123;phone-context=+1", expected: false},
+		{data1: "tel:7042;phone-context=example.com", data2: "tel:7042;phone-context=EXAMPLE.com", expected: true},
+		{data1: "tel:7042;phone-context=example.com", data2: "tel:7042;phone-context=example.org", expected: false},
+		{data1: "tel:+1-201-555-0123;a=b;c=d", data2: "tel:+12015550123;C=D;A=B", expected: true},
+		{data1: "tel:+1-201-555-0123;a=b", data2: "tel:+12015550123;a=b;c=d", expected: false},
+		{data1: "tel:+1-201-555-0123;ext=1", data2: "tel:+12015550123", expected: false},
+	}
+
+	for _, testCase := range tests {
+		testName := fmt.Sprintf("%v, %v", testCase.data1, testCase.data2)
+		t.Run(testName, func(t *testing.T) {
+			tel1, err := ParseTel([]byte(testCase.data1))
+			if err != nil {
+				t.Errorf("Failed to parse tel: %v", err.Error())
+				return
+			}
+			tel2, err := ParseTel([]byte(testCase.data2))
+			if err != nil {
+				t.Errorf("Failed to parse tel: %v", err.Error())
+				return
+			}
+			equals(testName, t, testCase.expected, tel1.Equivalent(tel2))
+		})
+	}
+}
+
+func TestTelString(t *testing.T) {
+	tel := &Tel{
+		Number:         "+12015550123",
+		IsdnSubaddress: "a;b",
+		Params:         []TelParam{{Name: "z", Value: "1"}, {Name: "a", Value: "[x]"}},
+	}
+	equals("String", t, "tel:+12015550123;isub=a%3Bb;a=%5Bx%5D;z=1", tel.String())
+}
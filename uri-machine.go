@@ -0,0 +1,567 @@
+package urip
+
+// runUriMachine parses URI (or URI-reference if reference is true) with a
+// state machine which reads each byte of data once, from left to right.
+//
+// NOTE
+// Parse finds the authority with backtracking. It tries "userinfo @" first,
+// and if "@" is not found, it goes back and tries "host : port" from the
+// same offset. The machine reads both at the same time instead. Until "@"
+// is found, it remembers the first ":" and where the digits following it
+// end, and splits host and port with them when the authority ends.
+//
+// NewHostFinder takes IPv4address before reg-name, so "1.2.3.4x" is found
+// as host "1.2.3.4" and the rest is not parsed. ipV4Prefix follows the host
+// to know where such an IPv4address ends.
+//
+// If entire is true, data is parsed as parseEntire does. The host is not cut
+// at such an IPv4address and is taken as reg-name, which is what a parser
+// without backtracking limits would find, and pct-encoded may have lowercase
+// hexadecimal digits.
+func runUriMachine(data []byte, dst *UriView, reference bool, entire bool) error {
+	*dst = UriView{}
+
+	state := uriStateStart
+	start := 0          // start of the current component
+	colon := -1         // first ":" in the authority
+	portEnd := -1       // end of the DIGITs following colon
+	var ipV4 ipV4Prefix // IPv4address at the start of the host
+
+	pos := 0
+	for {
+		// 0 is not in any class, so it also works as the end of data.
+		var c byte
+		if pos < len(data) {
+			c = data[pos]
+		}
+		pct := c == '%' && (isPctEncoded(data, pos) || entire && isPctEncodedNoCase(data, pos))
+
+		switch state {
+		case uriStateStart:
+			// RFC3986 - 3.1. Scheme
+			//
+			//  scheme = ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )
+			//
+			if isAlpha(c) {
+				state = uriStateScheme
+				pos++
+				continue
+			}
+			if !reference {
+				return errSchemeNotFound
+			}
+			state = uriStateRelativePart
+
+		case uriStateScheme:
+			if hasClass(c, classScheme) {
+				pos++
+				continue
+			}
+			if c == ':' {
+				dst.Scheme = Span{0, pos}
+				state = uriStateHierPart
+				pos++
+				continue
+			}
+			if !reference {
+				return errColonNotFound
+			}
+			// RFC3986 - 4.2. Relative Reference
+			//
+			//  path-noscheme = segment-nz-nc *( "/" segment )
+			//
+			// The bytes of scheme are also the bytes of segment-nz-nc.
+			state = uriStatePathNoScheme
+
+		case uriStateHierPart, uriStateRelativePart:
+			// RFC3986 - 3. Syntax Components
+			//
+			//  hier-part = "//" authority path-abempty
+			//            / path-absolute
+			//            / path-rootless
+			//            / path-empty
+			//
+			// RFC3986 - 4.2. Relative Reference
+			//
+			//  relative-part = "//" authority path-abempty
+			//                / path-absolute
+			//                / path-noscheme
+			//                / path-empty
+			//
+			start = pos
+			if c == '/' && pos+1 < len(data) && data[pos+1] == '/' {
+				dst.DoubleSlash = Span{pos, pos + 2}
+				pos += 2
+				start = pos
+				ipV4 = ipV4Prefix{}
+				state = uriStateAuthority
+				continue
+			}
+			if c == '/' || hasClass(c, classPchar) || pct {
+				if state == uriStateRelativePart && c != '/' {
+					state = uriStatePathNoScheme
+				} else {
+					state = uriStatePath
+				}
+				continue
+			}
+			// path-empty
+			state = uriStatePathEnd
+
+		case uriStateAuthority:
+			// RFC3986 - 3.2. Authority
+			//
+			//  authority = [ userinfo "@" ] host [ ":" port ]
+			//
+			if pos == start && c == '[' {
+				state = uriStateIpLiteral
+				pos++
+				continue
+			}
+			if hasClass(c, classUserInfo) || pct {
+				if c == ':' && colon < 0 {
+					colon = pos
+					portEnd = pos + 1
+				} else if portEnd == pos && isDigit(c) {
+					portEnd = pos + 1
+				}
+				ipV4.next(c, pos)
+				if pct {
+					pos += 3
+				} else {
+					pos++
+				}
+				continue
+			}
+			if c == '@' {
+				dst.UserInfo = Span{start, pos}
+				dst.AtSign = Span{pos, pos + 1}
+				pos++
+				start = pos
+				ipV4 = ipV4Prefix{}
+				state = uriStateHost
+				continue
+			}
+			// No "@". So the bytes read are "host [ : port ]".
+			hostEnd := pos
+			if colon >= 0 {
+				hostEnd = colon
+			}
+			if end, found := ipV4.match(); found && end < hostEnd && !entire {
+				dst.Host = Span{start, end}
+				dst.End = end
+				return nil
+			}
+			dst.Host = Span{start, hostEnd}
+			if colon >= 0 {
+				dst.Port = Span{colon + 1, portEnd}
+				if portEnd < pos {
+					dst.End = portEnd
+					return nil
+				}
+			}
+			state = uriStateAuthorityEnd
+
+		case uriStateHost:
+			// RFC3986 - 3.2.2. Host
+			//
+			//  host = IP-literal / IPv4address / reg-name
+			//
+			if pos == start && c == '[' {
+				state = uriStateIpLiteral
+				pos++
+				continue
+			}
+			if hasClass(c, classRegName) || pct {
+				ipV4.next(c, pos)
+				if pct {
+					pos += 3
+				} else {
+					pos++
+				}
+				continue
+			}
+			if end, found := ipV4.match(); found && end < pos && !entire {
+				dst.Host = Span{start, end}
+				dst.End = end
+				return nil
+			}
+			dst.Host = Span{start, pos}
+			state = uriStateHostEnd
+
+		case uriStateIpLiteral:
+			// RFC3986 - 3.2.2. Host
+			//
+			//  IP-literal = "[" ( IPv6address / IPvFuture  ) "]"
+			//
+			if hasClass(c, classUserInfo) {
+				pos++
+				continue
+			}
+			// NOTE
+			// The address is checked once more when "]" is found. Since it
+			// is checked only once, parsing is still O(n).
+			if c == ']' && isIpLiteral(data[start:pos+1]) {
+				pos++
+				dst.Host = Span{start, pos}
+				state = uriStateHostEnd
+				continue
+			}
+			// Neither IP-literal, IPv4address nor reg-name is found at "[".
+			// So the host is empty.
+			dst.Host = Span{start, start}
+			dst.End = start
+			return nil
+
+		case uriStateHostEnd:
+			if c == ':' {
+				pos++
+				start = pos
+				state = uriStatePort
+				continue
+			}
+			state = uriStateAuthorityEnd
+
+		case uriStatePort:
+			// RFC3986 - 3.2.3. Port
+			//
+			//  port = *DIGIT
+			//
+			if isDigit(c) {
+				pos++
+				continue
+			}
+			dst.Port = Span{start, pos}
+			state = uriStateAuthorityEnd
+
+		case uriStateAuthorityEnd:
+			// path-abempty
+			start = pos
+			if c == '/' {
+				state = uriStatePath
+				continue
+			}
+			state = uriStatePathEnd
+
+		case uriStatePathNoScheme:
+			// RFC3986 - 3.3. Path
+			//
+			//  segment-nz-nc = 1*( unreserved / pct-encoded / sub-delims / "@" )
+			//
+			if hasClass(c, classSegmentNzNc) || pct {
+				if pct {
+					pos += 3
+				} else {
+					pos++
+				}
+				continue
+			}
+			if c == '/' {
+				state = uriStatePath
+				continue
+			}
+			dst.Path = Span{start, pos}
+			state = uriStatePathEnd
+
+		case uriStatePath:
+			// RFC3986 - 3.3. Path
+			//
+			//  segment       = *pchar
+			//
+			if c == '/' || hasClass(c, classPchar) || pct {
+				if pct {
+					pos += 3
+				} else {
+					pos++
+				}
+				continue
+			}
+			dst.Path = Span{start, pos}
+			state = uriStatePathEnd
+
+		case uriStatePathEnd:
+			if c == '?' {
+				dst.Question = Span{pos, pos + 1}
+				pos++
+				start = pos
+				state = uriStateQuery
+				continue
+			}
+			if c == '#' {
+				dst.Sharp = Span{pos, pos + 1}
+				pos++
+				start = pos
+				state = uriStateFragment
+				continue
+			}
+			dst.End = pos
+			return nil
+
+		case uriStateQuery:
+			// RFC3986 - 3.4. Query
+			//
+			//  query = *( pchar / "/" / "?" )
+			//
+			if hasClass(c, classQuery) || pct {
+				if pct {
+					pos += 3
+				} else {
+					pos++
+				}
+				continue
+			}
+			dst.Query = Span{start, pos}
+			if c == '#' {
+				dst.Sharp = Span{pos, pos + 1}
+				pos++
+				start = pos
+				state = uriStateFragment
+				continue
+			}
+			dst.End = pos
+			return nil
+
+		case uriStateFragment:
+			// RFC3986 - 3.5. Fragment
+			//
+			//  fragment = *( pchar / "/" / "?" )
+			//
+			if hasClass(c, classQuery) || pct {
+				if pct {
+					pos += 3
+				} else {
+					pos++
+				}
+				continue
+			}
+			dst.Fragment = Span{start, pos}
+			dst.End = pos
+			return nil
+		}
+	}
+}
+
+type uriState int
+
+const (
+	uriStateStart uriState = iota
+	uriStateScheme
+	uriStateHierPart
+	uriStateRelativePart
+	uriStateAuthority
+	uriStateHost
+	uriStateIpLiteral
+	uriStateHostEnd
+	uriStatePort
+	uriStateAuthorityEnd
+	uriStatePathNoScheme
+	uriStatePath
+	uriStatePathEnd
+	uriStateQuery
+	uriStateFragment
+)
+
+// ipV4Prefix is given the bytes of a host one by one, and finds the
+// IPv4address at its start in the same way as NewIpV4AddressFinder.
+//
+// NOTE
+// dec-octet followed by "." matches only if all its DIGITs are one of the
+// alternatives. The last dec-octet is the first alternative which matches,
+// so "1.2.3.256" is found as "1.2.3.25".
+type ipV4Prefix struct {
+	octets  int // dec-octets followed by "."
+	digits  [3]byte
+	n       int // DIGITs of the current dec-octet
+	found   bool
+	end     int
+	stopped bool
+}
+
+func (ip *ipV4Prefix) next(c byte, pos int) {
+	if ip.stopped {
+		return
+	}
+	if isDigit(c) && ip.n < 3 {
+		ip.digits[ip.n] = c
+		ip.n++
+		if ip.octets == 3 {
+			ip.found = true
+			ip.end = pos - ip.n + 1 + scanDecOctet(ip.digits[:ip.n], 0)
+		}
+		return
+	}
+	if c == '.' && ip.octets < 3 && ip.n > 0 && scanDecOctet(ip.digits[:ip.n], 0) == ip.n {
+		ip.octets++
+		ip.n = 0
+		return
+	}
+	ip.stopped = true
+}
+
+func (ip *ipV4Prefix) match() (end int, found bool) {
+	return ip.end, ip.found
+}
+
+// isIpLiteral reports whether the whole data is found by NewIpLiteralFinder.
+//
+// NOTE
+// NewH16Finder has no lowercase HEXDIG, and NewIpV6AddressFinder does not
+// backtrack into ls32, so "[::ffff:192.0.2.1]" is not found. isIpV6Address
+// follows RFC3986 and finds more. The machine uses the finder here, so that
+// ParseInto finds the same host as Parse.
+func isIpLiteral(data []byte) bool {
+	found, end := NewIpLiteralFinder().Find(data)
+	return found && end == len(data)
+}
+
+// RFC3986 - 3.2.2. Host
+//
+//  IPvFuture = "v" 1*HEXDIG "." 1*( unreserved / sub-delims / ":" )
+//
+
+func isIpVFuture(data []byte) bool {
+	if len(data) == 0 || data[0] != 'v' {
+		return false
+	}
+	pos := 1
+	for pos < len(data) && isHexDigNoCase(data[pos]) {
+		pos++
+	}
+	if pos == 1 || pos == len(data) || data[pos] != '.' {
+		return false
+	}
+	pos++
+	if pos == len(data) {
+		return false
+	}
+	for ; pos < len(data); pos++ {
+		if !hasClass(data[pos], classUserInfo) {
+			return false
+		}
+	}
+	return true
+}
+
+// RFC3986 - 3.2.2. Host
+//
+//  IPv6address =                            6( h16 ":" ) ls32
+//              /                       "::" 5( h16 ":" ) ls32
+//              / [               h16 ] "::" 4( h16 ":" ) ls32
+//              / [ *1( h16 ":" ) h16 ] "::" 3( h16 ":" ) ls32
+//              / [ *2( h16 ":" ) h16 ] "::" 2( h16 ":" ) ls32
+//              / [ *3( h16 ":" ) h16 ] "::"    h16 ":"   ls32
+//              / [ *4( h16 ":" ) h16 ] "::"              ls32
+//              / [ *5( h16 ":" ) h16 ] "::"              h16
+//              / [ *6( h16 ":" ) h16 ] "::"
+//
+//  ls32        = ( h16 ":" h16 ) / IPv4address
+//
+
+func isIpV6Address(data []byte) bool {
+	// NOTE
+	// All the alternatives come down to the following.
+	// Without "::", there are exactly 8 pieces of 16 bits.
+	// With "::", there are at most 7 pieces, and "::" appears only once.
+	// An IPv4address counts as 2 pieces, and it is allowed only at the end.
+	pieces := 0
+	doubleColon := false
+	pos := 0
+	if len(data) >= 2 && data[0] == ':' && data[1] == ':' {
+		doubleColon = true
+		pos = 2
+	}
+	for pos < len(data) {
+		end := pos
+		for end < len(data) && end-pos < 4 && isHexDigNoCase(data[end]) {
+			end++
+		}
+		if end < len(data) && (data[end] == '.' || isHexDigNoCase(data[end])) {
+			// ls32 = IPv4address
+			if scanIpV4Address(data, pos) != len(data) {
+				return false
+			}
+			pieces += 2
+			break
+		}
+		if end == pos {
+			return false
+		}
+		pieces++
+		pos = end
+		if pos == len(data) {
+			break
+		}
+		if data[pos] != ':' {
+			return false
+		}
+		pos++
+		if pos < len(data) && data[pos] == ':' {
+			if doubleColon {
+				return false
+			}
+			doubleColon = true
+			pos++
+			continue
+		}
+		if pos == len(data) {
+			// ends with a single ":"
+			return false
+		}
+	}
+	if doubleColon {
+		return pieces <= 7
+	}
+	return pieces == 8
+}
+
+// RFC3986 - 3.2.2. Host
+//
+//  IPv4address = dec-octet "." dec-octet "." dec-octet "." dec-octet
+//
+
+func scanIpV4Address(data []byte, pos int) int {
+	start := pos
+	for i := 0; i < 3; i++ {
+		end := scanDecOctet(data, pos)
+		if end == pos || end == len(data) || data[end] != '.' {
+			return start
+		}
+		pos = end + 1
+	}
+	end := scanDecOctet(data, pos)
+	if end == pos {
+		return start
+	}
+	return end
+}
+
+// RFC3986 - 3.2.2. Host
+//
+//  dec-octet = DIGIT                 ; 0-9
+//            / %x31-39 DIGIT         ; 10-99
+//            / "1" 2DIGIT            ; 100-199
+//            / "2" %x30-34 DIGIT     ; 200-249
+//            / "25" %x30-35          ; 250-255
+//
+
+func scanDecOctet(data []byte, pos int) int {
+	// NOTE
+	// NewDecOctetFinder tries the longest alternatives first. So does this.
+	digits := 0
+	for pos+digits < len(data) && digits < 3 && isDigit(data[pos+digits]) {
+		digits++
+	}
+	if digits == 3 {
+		d := data[pos : pos+3]
+		if d[0] == '1' || (d[0] == '2' && d[1] <= '4') || (d[0] == '2' && d[1] == '5' && d[2] <= '5') {
+			return pos + 3
+		}
+	}
+	if digits >= 2 && data[pos] != '0' {
+		return pos + 2
+	}
+	if digits >= 1 {
+		return pos + 1
+	}
+	return pos
+}
@@ -0,0 +1,284 @@
+package urip
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestParseReferenceInto(t *testing.T) {
+	type TestCase struct {
+		testName         string
+		data             []byte
+		expectedScheme   []byte
+		expectedHost     []byte
+		expectedPath     []byte
+		expectedQuery    []byte
+		expectedFragment []byte
+		expectedEnd      int
+	}
+
+	tests := []TestCase{
+		{
+			testName:         "data: []byte(\"http://example.com/path?key=value#key=value\")",
+			data:             []byte("http://example.com/path?key=value#key=value"),
+			expectedScheme:   []byte("http"),
+			expectedHost:     []byte("example.com"),
+			expectedPath:     []byte("/path"),
+			expectedQuery:    []byte("key=value"),
+			expectedFragment: []byte("key=value"),
+			expectedEnd:      43,
+		},
+		{
+			testName:         "data: []byte(\"//example.com/path\")",
+			data:             []byte("//example.com/path"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte("example.com"),
+			expectedPath:     []byte("/path"),
+			expectedQuery:    []byte{},
+			expectedFragment: []byte{},
+			expectedEnd:      18,
+		},
+		{
+			testName:         "data: []byte(\"/path?key=value\")",
+			data:             []byte("/path?key=value"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte("/path"),
+			expectedQuery:    []byte("key=value"),
+			expectedFragment: []byte{},
+			expectedEnd:      15,
+		},
+		{
+			testName:         "data: []byte(\"path1/path2\")",
+			data:             []byte("path1/path2"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte("path1/path2"),
+			expectedQuery:    []byte{},
+			expectedFragment: []byte{},
+			expectedEnd:      11,
+		},
+		// path-noscheme: the first segment does not have ":".
+		{
+			testName:         "data: []byte(\"1a:b/c\")",
+			data:             []byte("1a:b/c"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte("1a"),
+			expectedQuery:    []byte{},
+			expectedFragment: []byte{},
+			expectedEnd:      2,
+		},
+		{
+			testName:         "data: []byte(\"a/b:c\")",
+			data:             []byte("a/b:c"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte("a/b:c"),
+			expectedQuery:    []byte{},
+			expectedFragment: []byte{},
+			expectedEnd:      5,
+		},
+		{
+			testName:         "data: []byte(\"?key=value\")",
+			data:             []byte("?key=value"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte{},
+			expectedQuery:    []byte("key=value"),
+			expectedFragment: []byte{},
+			expectedEnd:      10,
+		},
+		{
+			testName:         "data: []byte(\"#key=value\")",
+			data:             []byte("#key=value"),
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte{},
+			expectedQuery:    []byte{},
+			expectedFragment: []byte("key=value"),
+			expectedEnd:      10,
+		},
+		{
+			testName:         "data: []byte{}",
+			data:             []byte{},
+			expectedScheme:   []byte{},
+			expectedHost:     []byte{},
+			expectedPath:     []byte{},
+			expectedQuery:    []byte{},
+			expectedFragment: []byte{},
+			expectedEnd:      0,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			var view UriView
+			ParseReferenceInto(testCase.data, &view)
+			byteEquals(testCase.testName+"(Scheme)", t, testCase.expectedScheme, view.Scheme.Bytes(testCase.data))
+			byteEquals(testCase.testName+"(Host)", t, testCase.expectedHost, view.Host.Bytes(testCase.data))
+			byteEquals(testCase.testName+"(Path)", t, testCase.expectedPath, view.Path.Bytes(testCase.data))
+			byteEquals(testCase.testName+"(Query)", t, testCase.expectedQuery, view.Query.Bytes(testCase.data))
+			byteEquals(testCase.testName+"(Fragment)", t, testCase.expectedFragment, view.Fragment.Bytes(testCase.data))
+			equals(testCase.testName+"(End)", t, testCase.expectedEnd, view.End)
+		})
+	}
+}
+
+// parseDifferential checks that ParseInto finds the same components as
+// Parse, and that the components cover data[:End].
+func parseDifferential(t *testing.T, data []byte) {
+	expected, expectedErr := Parse(data)
+	var view UriView
+	err := ParseInto(data, &view)
+	if expectedErr != nil || err != nil {
+		equals("error", t, fmt.Sprint(expectedErr), fmt.Sprint(err))
+		return
+	}
+
+	var reference UriView
+	ParseReferenceInto(data, &reference)
+	if reference != view {
+		t.Errorf("data: %q: ParseReferenceInto: %+v, ParseInto: %+v", data, reference, view)
+	}
+
+	actual := view.Uri(data)
+	if !bytes.Equal(expected.Scheme, actual.Scheme) ||
+		!bytes.Equal(expected.DoubleSlash, actual.DoubleSlash) ||
+		!bytes.Equal(expected.UserInfo, actual.UserInfo) ||
+		!bytes.Equal(expected.AtSign, actual.AtSign) ||
+		!bytes.Equal(expected.Host, actual.Host) ||
+		!bytes.Equal(expected.Port, actual.Port) ||
+		!bytes.Equal(expected.Path, actual.Path) ||
+		!bytes.Equal(expected.Question, actual.Question) ||
+		!bytes.Equal(expected.Query, actual.Query) ||
+		!bytes.Equal(expected.Sharp, actual.Sharp) ||
+		!bytes.Equal(expected.Fragment, actual.Fragment) {
+		t.Errorf("data: %q: expected: %q, actual: %q", data, expected, actual)
+	}
+}
+
+// referenceDifferential checks that ParseReferenceInto ends where
+// NewUriReferenceFinder does.
+//
+// NOTE
+// NewRelativePartFinder is NewHierPartFinder, so the first segment of a
+// relative-ref may have ":" (path-rootless) in the finder. ParseReferenceInto
+// follows RFC3986 and stops at the ":" (path-noscheme). For example,
+// "1http://example.com" ends at 19 in the finder, and at 5 in the machine.
+//
+// NewUriFinder panics in abnfp for a scheme which is not followed by ":" and
+// has two or more letters, such as "http". Such data is skipped.
+func referenceDifferential(t *testing.T, data []byte) {
+	var view UriView
+	ParseReferenceInto(data, &view)
+	expectedEnd, ok := findUriReference(data)
+	if !ok || expectedEnd == view.End {
+		return
+	}
+	noScheme := view.Scheme.Len() == 0 && view.DoubleSlash.Len() == 0 &&
+		view.End < len(data) && data[view.End] == ':'
+	if noScheme && expectedEnd > view.End {
+		return
+	}
+	t.Errorf("data: %q: NewUriReferenceFinder: %v, ParseReferenceInto: %v", data, expectedEnd, view.End)
+}
+
+func findUriReference(data []byte) (end int, ok bool) {
+	defer func() {
+		if recover() != nil {
+			end, ok = 0, false
+		}
+	}()
+	_, end = NewUriReferenceFinder().Find(data)
+	return end, true
+}
+
+func TestParseIntoDifferential(t *testing.T) {
+	for _, data := range uriCorpus {
+		parseDifferential(t, []byte(data))
+	}
+
+	// Parse leaves the host of these empty. See isIpLiteral.
+	for _, data := range []string{"http://[2001:db8::1]/", "http://[::ffff:192.0.2.1]/", "//[fe80::1]/path"} {
+		parseDifferential(t, []byte(data))
+		referenceDifferential(t, []byte(data))
+
+		var view UriView
+		ParseReferenceInto([]byte(data), &view)
+		if view.Host.Len() != 0 {
+			t.Errorf("data: %q: ParseReferenceInto finds the IP-literal: %+v", data, view)
+		}
+	}
+}
+
+func TestParseReferenceIntoDifferential(t *testing.T) {
+	for _, data := range uriCorpus {
+		referenceDifferential(t, []byte(data))
+	}
+
+	// The divergences are the ones documented above.
+	for _, data := range []string{"1http://example.com", "1a:b/c"} {
+		var view UriView
+		ParseReferenceInto([]byte(data), &view)
+		end, _ := findUriReference([]byte(data))
+		if end == view.End {
+			t.Errorf("data: %q: ParseReferenceInto ends at %v as the finder does", data, end)
+		}
+	}
+}
+
+func FuzzParseInto(f *testing.F) {
+	for _, data := range uriCorpus {
+		f.Add([]byte(data))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		parseDifferential(t, data)
+	})
+}
+
+func FuzzParseReferenceIntoDifferential(f *testing.F) {
+	for _, data := range uriCorpus {
+		f.Add([]byte(data))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		referenceDifferential(t, data)
+	})
+}
+
+func FuzzParseReferenceInto(f *testing.F) {
+	for _, data := range uriCorpus {
+		f.Add([]byte(data))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var view UriView
+		ParseReferenceInto(data, &view)
+		if view.End < 0 || view.End > len(data) {
+			t.Fatalf("data: %q: End %v is out of range", data, view.End)
+		}
+		// All the components are in data[:End] and in order.
+		spans := []Span{
+			view.Scheme, view.DoubleSlash, view.UserInfo, view.AtSign, view.Host,
+			view.Port, view.Path, view.Question, view.Query, view.Sharp, view.Fragment,
+		}
+		last := 0
+		for _, span := range spans {
+			if span.Len() == 0 {
+				continue
+			}
+			if span.Start < last || span.End > view.End {
+				t.Fatalf("data: %q: %+v is out of order", data, view)
+			}
+			last = span.End
+		}
+	})
+}
+
+func BenchmarkParseReferenceInto(b *testing.B) {
+	b.ReportAllocs()
+	var view UriView
+	for i := 0; i < b.N; i++ {
+		ParseReferenceInto(benchmarkUri, &view)
+	}
+}
@@ -178,14 +178,18 @@ func NewAuthorityFinder() abnfp.Finder {
 //
 
 func NewUserInfoFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionFinder(
-		abnfp.NewAlternativesFinder([]abnfp.Finder{
-			NewUnreservedFinder(),
-			NewPctEncodedFinder(),
-			NewSubDelimsFinder(),
-			abnfp.NewByteFinder(':'),
-		}),
-	)
+	// NOTE
+	// This is the same as the following, but uses the charClasses table.
+	//
+	//  abnfp.NewVariableRepetitionFinder(
+	//  	abnfp.NewAlternativesFinder([]abnfp.Finder{
+	//  		NewUnreservedFinder(),
+	//  		NewPctEncodedFinder(),
+	//  		NewSubDelimsFinder(),
+	//  		abnfp.NewByteFinder(':'),
+	//  	}),
+	//  )
+	return newClassRunFinder(0, classUserInfo)
 }
 
 // RFC3986 - 3.2.2. Host
@@ -484,13 +488,17 @@ func NewDecOctetFinder() abnfp.Finder {
 //
 
 func NewRegNameFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionFinder(
-		abnfp.NewAlternativesFinder([]abnfp.Finder{
-			NewUnreservedFinder(),
-			NewPctEncodedFinder(),
-			NewSubDelimsFinder(),
-		}),
-	)
+	// NOTE
+	// This is the same as the following, but uses the charClasses table.
+	//
+	//  abnfp.NewVariableRepetitionFinder(
+	//  	abnfp.NewAlternativesFinder([]abnfp.Finder{
+	//  		NewUnreservedFinder(),
+	//  		NewPctEncodedFinder(),
+	//  		NewSubDelimsFinder(),
+	//  	}),
+	//  )
+	return newClassRunFinder(0, classRegName)
 }
 
 // RFC3986 - 3.2.3. Port
@@ -601,7 +609,10 @@ func NewPathEmptyFinder() abnfp.Finder {
 //
 
 func NewSegmentFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionFinder(NewPcharFinder())
+	// NOTE
+	// This is the same as abnfp.NewVariableRepetitionFinder(NewPcharFinder()),
+	// but uses the charClasses table.
+	return newClassRunFinder(0, classPchar)
 }
 
 // RFC3986 - 3.3. Path
@@ -610,7 +621,10 @@ func NewSegmentFinder() abnfp.Finder {
 //
 
 func NewSegmentNzFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionMinFinder(1, NewPcharFinder())
+	// NOTE
+	// This is the same as abnfp.NewVariableRepetitionMinFinder(1, NewPcharFinder()),
+	// but uses the charClasses table.
+	return newClassRunFinder(1, classPchar)
 }
 
 // RFC3986 - 3.3. Path
@@ -620,14 +634,18 @@ func NewSegmentNzFinder() abnfp.Finder {
 //
 
 func NewSegmentNzNcFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionMinFinder(1,
-		abnfp.NewAlternativesFinder([]abnfp.Finder{
-			NewUnreservedFinder(),
-			NewPctEncodedFinder(),
-			NewSubDelimsFinder(),
-			abnfp.NewByteFinder('@'),
-		}),
-	)
+	// NOTE
+	// This is the same as the following, but uses the charClasses table.
+	//
+	//  abnfp.NewVariableRepetitionMinFinder(1,
+	//  	abnfp.NewAlternativesFinder([]abnfp.Finder{
+	//  		NewUnreservedFinder(),
+	//  		NewPctEncodedFinder(),
+	//  		NewSubDelimsFinder(),
+	//  		abnfp.NewByteFinder('@'),
+	//  	}),
+	//  )
+	return newClassRunFinder(1, classSegmentNzNc)
 }
 
 // RFC3986 - 3.3. Path
@@ -651,13 +669,17 @@ func NewPcharFinder() abnfp.Finder {
 //
 
 func NewQueryFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionFinder(
-		abnfp.NewAlternativesFinder([]abnfp.Finder{
-			NewPcharFinder(),
-			abnfp.NewByteFinder('/'),
-			abnfp.NewByteFinder('?'),
-		}),
-	)
+	// NOTE
+	// This is the same as the following, but uses the charClasses table.
+	//
+	//  abnfp.NewVariableRepetitionFinder(
+	//  	abnfp.NewAlternativesFinder([]abnfp.Finder{
+	//  		NewPcharFinder(),
+	//  		abnfp.NewByteFinder('/'),
+	//  		abnfp.NewByteFinder('?'),
+	//  	}),
+	//  )
+	return newClassRunFinder(0, classQuery)
 }
 
 // RFC3986 - 3.5. Fragment
@@ -666,13 +688,17 @@ func NewQueryFinder() abnfp.Finder {
 //
 
 func NewFragmentFinder() abnfp.Finder {
-	return abnfp.NewVariableRepetitionFinder(
-		abnfp.NewAlternativesFinder([]abnfp.Finder{
-			NewPcharFinder(),
-			abnfp.NewByteFinder('/'),
-			abnfp.NewByteFinder('?'),
-		}),
-	)
+	// NOTE
+	// This is the same as the following, but uses the charClasses table.
+	//
+	//  abnfp.NewVariableRepetitionFinder(
+	//  	abnfp.NewAlternativesFinder([]abnfp.Finder{
+	//  		NewPcharFinder(),
+	//  		abnfp.NewByteFinder('/'),
+	//  		abnfp.NewByteFinder('?'),
+	//  	}),
+	//  )
+	return newClassRunFinder(0, classQuery)
 }
 
 // RFC3986 - 4.1. URI Reference
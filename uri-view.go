@@ -0,0 +1,75 @@
+package urip
+
+// Span is a half-open range [Start, End) of byte offsets into the data
+// passed to ParseInto.
+type Span struct {
+	Start int
+	End   int
+}
+
+func (span Span) Len() int {
+	return span.End - span.Start
+}
+
+func (span Span) Bytes(data []byte) []byte {
+	return data[span.Start:span.End]
+}
+
+// UriView holds the same components as Uri, but as offsets into the parsed
+// data instead of sub-slices. A component which is not present has zero
+// length.
+//
+// Like Parse, ParseInto and ParseReferenceInto stop at the first byte which
+// cannot continue the syntax. End is the offset of that byte, or len(data)
+// if all the data was parsed.
+type UriView struct {
+	Scheme      Span
+	DoubleSlash Span // part of hier-part
+	UserInfo    Span // part of hier-part
+	AtSign      Span // part of hier-part
+	Host        Span // part of hier-part
+	Port        Span // part of hier-part
+	Path        Span // part of hier-part
+	Question    Span
+	Query       Span
+	Sharp       Span
+	Fragment    Span
+	End         int
+}
+
+// Uri converts the view into a Uri whose components are sub-slices of data.
+// data must be the slice which was passed to ParseInto.
+func (view *UriView) Uri(data []byte) *Uri {
+	return &Uri{
+		Scheme:      view.Scheme.Bytes(data),
+		DoubleSlash: view.DoubleSlash.Bytes(data),
+		UserInfo:    view.UserInfo.Bytes(data),
+		AtSign:      view.AtSign.Bytes(data),
+		Host:        view.Host.Bytes(data),
+		Port:        view.Port.Bytes(data),
+		Path:        view.Path.Bytes(data),
+		Question:    view.Question.Bytes(data),
+		Query:       view.Query.Bytes(data),
+		Sharp:       view.Sharp.Bytes(data),
+		Fragment:    view.Fragment.Bytes(data),
+	}
+}
+
+// ParseInto parses data in the same way as Parse, but stores the component
+// offsets into dst instead of allocating a Uri.
+func ParseInto(data []byte, dst *UriView) error {
+	return runUriMachine(data, dst, false, false)
+}
+
+// ParseReferenceInto is ParseInto for URI-reference. If data does not start
+// with a scheme and ":", it is parsed as a relative-ref and dst.Scheme has
+// zero length. Since a relative-ref can be empty, it never fails.
+//
+// NOTE
+// The first segment of a relative path is path-noscheme, which cannot have
+// ":". NewRelativePartFinder is NewHierPartFinder and takes path-rootless
+// there, so "1http://example.com" is found to the end by
+// NewUriReferenceFinder, but ParseReferenceInto stops at the ":".
+func ParseReferenceInto(data []byte, dst *UriView) {
+	runUriMachine(data, dst, true, false)
+}
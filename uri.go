@@ -6,6 +6,14 @@ import (
 	abnfp "github.com/um7a/abnf-parser"
 )
 
+var (
+	errSchemeNotFound       = errors.New("scheme not found.")
+	errColonNotFound        = errors.New("colon following scheme not found.")
+	errPathAbsoluteNotFound = errors.New("path-absolute not found.")
+	errPathRootlessNotFound = errors.New("path-rootless not found.")
+	errTrailingData         = errors.New("data following URI found.")
+)
+
 type Uri struct {
 	Scheme      []byte
 	DoubleSlash []byte // part of hier-part
@@ -37,14 +45,14 @@ func Parse(data []byte) (uri *Uri, err error) {
 	//
 	parsed, remaining := abnfp.Parse(data, NewSchemeFinder())
 	if len(parsed) == 0 {
-		return nil, errors.New("scheme not found.")
+		return nil, errSchemeNotFound
 	}
 	uri.Scheme = parsed
 
 	// ":"
 	found, end := abnfp.NewByteFinder(':').Find(remaining)
 	if !found {
-		return nil, errors.New("colon following scheme not found.")
+		return nil, errColonNotFound
 	}
 	remaining = remaining[end:]
 
@@ -123,7 +131,7 @@ func Parse(data []byte) (uri *Uri, err error) {
 		//
 		parsed, hierPartRemaining = abnfp.Parse(parsed, NewPathAbsoluteFinder())
 		if len(parsed) == 0 {
-			return nil, errors.New("path-absolute not found.")
+			return nil, errPathAbsoluteNotFound
 		}
 		uri.Path = parsed
 	} else if len(parsed) > 0 {
@@ -135,7 +143,7 @@ func Parse(data []byte) (uri *Uri, err error) {
 		//
 		parsed, hierPartRemaining = abnfp.Parse(parsed, NewPathRootlessFinder())
 		if len(parsed) == 0 {
-			return nil, errors.New("path-rootless not found.")
+			return nil, errPathRootlessNotFound
 		}
 		uri.Path = parsed
 	} else {
@@ -170,6 +178,27 @@ func Parse(data []byte) (uri *Uri, err error) {
 	return uri, nil
 }
 
+// parseEntire is Parse which fails if data has bytes following the URI.
+// Parse stops at the first byte which cannot continue the syntax, and
+// ignores the rest of data.
+//
+// NOTE
+// Parse finds host "1.2.3.4" in "http://1.2.3.4.example.com/", since
+// NewHostFinder takes IPv4address first and does not backtrack. Such a host
+// is taken as reg-name here, so that a valid URI is not rejected.
+// pct-encoded may also have lowercase hexadecimal digits, such as "%2f",
+// which RFC3986 - 2.1 says are equivalent to uppercase ones.
+func parseEntire(data []byte) (*Uri, error) {
+	var view UriView
+	if err := runUriMachine(data, &view, false, true); err != nil {
+		return nil, err
+	}
+	if view.End != len(data) {
+		return nil, errTrailingData
+	}
+	return view.Uri(data), nil
+}
+
 func (uri *Uri) String() string {
 	// RFC3986 - 3. Syntax Components
 	//
@@ -477,3 +477,51 @@ func TestUri(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEntire(t *testing.T) {
+	uri, err := parseEntire([]byte("http://example.com/path"))
+	if err != nil {
+		t.Errorf("Failed to parse Uri: %v", err.Error())
+		return
+	}
+	byteEquals("Path", t, []byte("/path"), uri.Path)
+
+	_, err = parseEntire([]byte("http://example.com/path with space"))
+	if err != errTrailingData {
+		t.Errorf("expected: %v, actual: %v", errTrailingData, err)
+	}
+
+	// pct-encoded may have lowercase hexadecimal digits, which Parse does
+	// not find.
+	uri, err = Parse([]byte("http://a/%e3"))
+	if err != nil {
+		t.Errorf("Failed to parse Uri: %v", err.Error())
+		return
+	}
+	byteEquals("Parse(Path)", t, []byte("/"), uri.Path)
+	uri, err = parseEntire([]byte("http://a/%e3"))
+	if err != nil {
+		t.Errorf("Failed to parse Uri: %v", err.Error())
+		return
+	}
+	byteEquals("parseEntire(Path)", t, []byte("/%e3"), uri.Path)
+
+	// The host which starts with IPv4address is reg-name.
+	hosts := map[string]string{
+		"http://1.2.3.4.example.com/": "1.2.3.4.example.com",
+		"http://10.0.0.1x/":           "10.0.0.1x",
+		"http://1.2.3.256":            "1.2.3.256",
+		"http://user@10.0.0.1x:80/":   "10.0.0.1x",
+		"http://1.2.3.4:80/":          "1.2.3.4",
+		"http://1.2.3.4.nip.io?q#f":   "1.2.3.4.nip.io",
+	}
+	for data, host := range hosts {
+		uri, err := parseEntire([]byte(data))
+		if err != nil {
+			t.Errorf("%s: %v", data, err)
+			continue
+		}
+		byteEquals(data+"(Host)", t, []byte(host), uri.Host)
+		equals(data+"(String)", t, data, uri.String())
+	}
+}
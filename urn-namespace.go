@@ -0,0 +1,276 @@
+package urip
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	errNidMismatch    = errors.New("NID mismatch.")
+	errUuidNotFound   = errors.New("UUID not found.")
+	errIsbnNotFound   = errors.New("ISBN not found.")
+	errIsbnCheckDigit = errors.New("check digit of ISBN mismatch.")
+	errOidNotFound    = errors.New("OID not found.")
+	errIetfNssInvalid = errors.New("invalid NSS of ietf namespace.")
+	errIssnNotFound   = errors.New("ISSN not found.")
+	errIssnCheckDigit = errors.New("check digit of ISSN mismatch.")
+)
+
+// UrnValidator validates the NSS of a URN of a namespace.
+type UrnValidator func(urn *Urn) error
+
+var (
+	urnValidatorsMutex sync.RWMutex
+	urnValidators      = map[string]UrnValidator{
+		"uuid": func(urn *Urn) error { _, err := urn.Uuid(); return err },
+		"isbn": func(urn *Urn) error { _, err := urn.Isbn(); return err },
+		"oid":  func(urn *Urn) error { _, err := urn.Oid(); return err },
+		"ietf": validateIetf,
+		"issn": func(urn *Urn) error { _, err := urn.Issn(); return err },
+	}
+)
+
+// RegisterUrnValidator registers the validator of the namespace of nid.
+// The validator registered for the same NID is replaced.
+func RegisterUrnValidator(nid string, validator UrnValidator) {
+	urnValidatorsMutex.Lock()
+	defer urnValidatorsMutex.Unlock()
+	urnValidators[strings.ToLower(nid)] = validator
+}
+
+// Validate validates the NSS with the validator of the namespace.
+// A URN of a namespace without a validator is valid.
+func (urn *Urn) Validate() error {
+	urnValidatorsMutex.RLock()
+	validator, found := urnValidators[strings.ToLower(urn.Nid)]
+	urnValidatorsMutex.RUnlock()
+	if !found {
+		return nil
+	}
+	return validator(urn)
+}
+
+func (urn *Urn) nssOf(nid string) (string, error) {
+	if !strings.EqualFold(urn.Nid, nid) {
+		return "", errNidMismatch
+	}
+	return urn.Nss, nil
+}
+
+// Uuid returns the UUID of the uuid namespace.
+//
+// RFC4122 - 3. Namespace Registration Template
+//
+//	UUID                   = time-low "-" time-mid "-"
+//	                         time-high-and-version "-"
+//	                         clock-seq-and-reserved
+//	                         clock-seq-low "-" node
+//	time-low               = 4hexOctet
+//	time-mid               = 2hexOctet
+//	time-high-and-version  = 2hexOctet
+//	clock-seq-and-reserved = hexOctet
+//	clock-seq-low          = hexOctet
+//	node                   = 6hexOctet
+//	hexOctet               = hexDigit hexDigit
+func (urn *Urn) Uuid() ([16]byte, error) {
+	var uuid [16]byte
+	nss, err := urn.nssOf("uuid")
+	if err != nil {
+		return uuid, err
+	}
+	if len(nss) != 36 {
+		return uuid, errUuidNotFound
+	}
+	j := 0
+	for i := 0; i < len(nss); i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if nss[i] != '-' {
+				return uuid, errUuidNotFound
+			}
+			continue
+		}
+		if !isHexDigNoCase(nss[i]) || !isHexDigNoCase(nss[i+1]) {
+			return uuid, errUuidNotFound
+		}
+		uuid[j] = hexValue(nss[i])<<4 | hexValue(nss[i+1])
+		j++
+		i++
+	}
+	return uuid, nil
+}
+
+// Isbn returns the ISBN-10 or ISBN-13 of the isbn namespace without hyphens.
+// The check digit is validated.
+//
+// RFC8254 - 4. Namespace Registration
+// The NSS is an ISBN, which may contain hyphens.
+func (urn *Urn) Isbn() (string, error) {
+	nss, err := urn.nssOf("isbn")
+	if err != nil {
+		return "", err
+	}
+	isbn := strings.ToUpper(strings.ReplaceAll(nss, "-", ""))
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i := 0; i < 10; i++ {
+			c := isbn[i]
+			switch {
+			case isDigit(c):
+				sum += (10 - i) * int(c-'0')
+			case c == 'X' && i == 9:
+				sum += 10
+			default:
+				return "", errIsbnNotFound
+			}
+		}
+		if sum%11 != 0 {
+			return "", errIsbnCheckDigit
+		}
+	case 13:
+		sum := 0
+		for i := 0; i < 13; i++ {
+			c := isbn[i]
+			if !isDigit(c) {
+				return "", errIsbnNotFound
+			}
+			sum += (1 + 2*(i%2)) * int(c-'0')
+		}
+		if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+			return "", errIsbnNotFound
+		}
+		if sum%10 != 0 {
+			return "", errIsbnCheckDigit
+		}
+	default:
+		return "", errIsbnNotFound
+	}
+	return isbn, nil
+}
+
+// Oid returns the arcs of the OID of the oid namespace. The arcs are kept
+// decimal strings, since an arc such as that of 2.25 can exceed uint64.
+//
+// RFC3061 - 2. Specification Template
+//
+//	oid       = number *( DOT number )
+//	number    = DIGIT / ( LEADDIGIT 1*DIGIT )
+//	LEADDIGIT = %x31-39 ; 1-9
+func (urn *Urn) Oid() ([]string, error) {
+	nss, err := urn.nssOf("oid")
+	if err != nil {
+		return nil, err
+	}
+	arcs := strings.Split(nss, ".")
+	for _, arc := range arcs {
+		if !isNumber(arc) {
+			return nil, errOidNotFound
+		}
+	}
+	return arcs, nil
+}
+
+// RfcNumber returns the number of the RFC of "urn:ietf:rfc:NNNN".
+func (urn *Urn) RfcNumber() (int, error) {
+	nss, err := urn.nssOf("ietf")
+	if err != nil {
+		return 0, err
+	}
+	kind, number, found := strings.Cut(nss, ":")
+	if !found || !strings.EqualFold(kind, "rfc") || !isNumber(number) {
+		return 0, errIetfNssInvalid
+	}
+	return strconv.Atoi(number)
+}
+
+// RFC2648 - 3. Specification Template
+//
+//	NSS         = rfc-nss / fyi-nss / std-nss / bcp-nss /
+//	              draft-nss / mtg-nss / other-nss
+//	rfc-nss     = "rfc:" 1*DIGIT
+//	fyi-nss     = "fyi:" 1*DIGIT
+//	std-nss     = "std:" 1*DIGIT
+//	bcp-nss     = "bcp:" 1*DIGIT
+//	draft-nss   = "id:" string
+//	mtg-nss     = "mtg-" string
+//	other-nss   = string
+//
+// The other-nss is not accepted here, since it is reserved for future use.
+// "params:" of RFC3553 is accepted.
+
+func validateIetf(urn *Urn) error {
+	nss, err := urn.nssOf("ietf")
+	if err != nil {
+		return err
+	}
+	kind, rest, found := strings.Cut(nss, ":")
+	switch strings.ToLower(kind) {
+	case "rfc", "fyi", "std", "bcp":
+		if found && isDigits(rest) {
+			return nil
+		}
+	case "id", "params":
+		if found && len(rest) > 0 {
+			return nil
+		}
+	default:
+		if len(kind) > 4 && strings.EqualFold(kind[:4], "mtg-") {
+			return nil
+		}
+	}
+	return errIetfNssInvalid
+}
+
+// Issn returns the ISSN of the issn namespace in "NNNN-NNNC" form.
+// The check digit is validated.
+//
+// RFC3044 - 2. Specification Template
+//
+//	ISSN = 4DIGIT "-" 3DIGIT ( DIGIT / "X" )
+func (urn *Urn) Issn() (string, error) {
+	nss, err := urn.nssOf("issn")
+	if err != nil {
+		return "", err
+	}
+	issn := strings.ToUpper(nss)
+	if len(issn) != 9 || issn[4] != '-' {
+		return "", errIssnNotFound
+	}
+	digits := issn[:4] + issn[5:]
+	sum := 0
+	for i := 0; i < 8; i++ {
+		c := digits[i]
+		switch {
+		case isDigit(c):
+			sum += (8 - i) * int(c-'0')
+		case c == 'X' && i == 7:
+			sum += 10
+		default:
+			return "", errIssnNotFound
+		}
+	}
+	if sum%11 != 0 {
+		return "", errIssnCheckDigit
+	}
+	return issn, nil
+}
+
+// isNumber reports whether str is a decimal number without leading zeros.
+func isNumber(str string) bool {
+	return isDigits(str) && (str[0] != '0' || len(str) == 1)
+}
+
+// isDigits reports whether str is 1*DIGIT.
+func isDigits(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package urip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUrnValidate(t *testing.T) {
+	type TestCase struct {
+		data        string
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{data: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"},
+		{data: "urn:UUID:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"},
+		{data: "urn:uuid:f81d4fae7dec11d0a76500a0c91e6bf6", expectedErr: errUuidNotFound},
+		{data: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bfg", expectedErr: errUuidNotFound},
+		{data: "urn:isbn:0451450523"},
+		{data: "urn:isbn:0-8044-2957-X"},
+		{data: "urn:isbn:978-0-306-40615-7"},
+		{data: "urn:isbn:0451450524", expectedErr: errIsbnCheckDigit},
+		{data: "urn:isbn:978-0-306-40615-6", expectedErr: errIsbnCheckDigit},
+		{data: "urn:isbn:123-0-306-40615-7", expectedErr: errIsbnNotFound},
+		{data: "urn:isbn:04514505", expectedErr: errIsbnNotFound},
+		{data: "urn:oid:1.3.6.1.4.1"},
+		{data: "urn:oid:2.25.329800735698586629295641978511506172918"},
+		{data: "urn:oid:1.03.6", expectedErr: errOidNotFound},
+		{data: "urn:oid:1..6", expectedErr: errOidNotFound},
+		{data: "urn:ietf:rfc:2648"},
+		{data: "urn:ietf:bcp:14"},
+		{data: "urn:ietf:id:ietf-urn-ietf-06"},
+		{data: "urn:ietf:mtg-41-urn"},
+		{data: "urn:ietf:params:xml:ns:netconf:base:1.0"},
+		{data: "urn:ietf:rfc:abc", expectedErr: errIetfNssInvalid},
+		{data: "urn:ietf:foo", expectedErr: errIetfNssInvalid},
+		{data: "urn:issn:0259-000X"},
+		{data: "urn:issn:1560-1560"},
+		{data: "urn:issn:0259-0001", expectedErr: errIssnCheckDigit},
+		{data: "urn:issn:02590001", expectedErr: errIssnNotFound},
+		{data: "urn:example:anything"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			urn, err := ParseUrn([]byte(testCase.data))
+			if err != nil {
+				t.Errorf("Failed to parse urn: %v", err.Error())
+				return
+			}
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(urn.Validate()))
+		})
+	}
+}
+
+func TestUrnAccessors(t *testing.T) {
+	urn, _ := ParseUrn([]byte("urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"))
+	uuid, err := urn.Uuid()
+	equals("Uuid", t, "<nil>", fmt.Sprint(err))
+	equals("Uuid", t, [16]byte{
+		0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0,
+		0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6,
+	}, uuid)
+	_, err = urn.Isbn()
+	equals("Isbn", t, fmt.Sprint(errNidMismatch), fmt.Sprint(err))
+
+	urn, _ = ParseUrn([]byte("urn:isbn:0-8044-2957-x"))
+	isbn, _ := urn.Isbn()
+	equals("Isbn", t, "080442957X", isbn)
+
+	urn, _ = ParseUrn([]byte("urn:oid:1.3.6.1"))
+	oid, _ := urn.Oid()
+	sliceHasSameElem("Oid", t, []string{"1", "3", "6", "1"}, oid)
+
+	urn, _ = ParseUrn([]byte("urn:ietf:rfc:8141"))
+	number, _ := urn.RfcNumber()
+	equals("RfcNumber", t, 8141, number)
+	urn, _ = ParseUrn([]byte("urn:ietf:bcp:14"))
+	_, err = urn.RfcNumber()
+	equals("RfcNumber", t, fmt.Sprint(errIetfNssInvalid), fmt.Sprint(err))
+
+	urn, _ = ParseUrn([]byte("urn:issn:0259-000x"))
+	issn, _ := urn.Issn()
+	equals("Issn", t, "0259-000X", issn)
+}
+
+func TestRegisterUrnValidator(t *testing.T) {
+	errCatalogue := errors.New("unknown catalogue entry.")
+	RegisterUrnValidator("X-Catalogue", func(urn *Urn) error {
+		if !strings.HasPrefix(urn.Nss, "entry:") {
+			return errCatalogue
+		}
+		return nil
+	})
+	defer func() {
+		urnValidatorsMutex.Lock()
+		delete(urnValidators, "x-catalogue")
+		urnValidatorsMutex.Unlock()
+	}()
+
+	urn, _ := ParseUrn([]byte("urn:x-catalogue:entry:42"))
+	equals("valid", t, "<nil>", fmt.Sprint(urn.Validate()))
+	urn, _ = ParseUrn([]byte("urn:X-CATALOGUE:42"))
+	equals("invalid", t, fmt.Sprint(errCatalogue), fmt.Sprint(urn.Validate()))
+}
@@ -0,0 +1,134 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	errUrnSchemeNotFound = errors.New("urn scheme not found.")
+	errUrnAuthority      = errors.New("urn cannot have authority.")
+	errNidNotFound       = errors.New("NID not found.")
+	errNssNotFound       = errors.New("NSS not found.")
+	errRqComponents      = errors.New("r-component or q-component not found.")
+)
+
+// Urn is a URN.
+// The components are kept pct-encoded, since a pct-encoded in NSS is not
+// equivalent to the octet it represents.
+type Urn struct {
+	Nid        string
+	Nss        string
+	RComponent string // without "?+"
+	QComponent string // without "?="
+	FComponent string // without "#"
+}
+
+// ParseUrn parses a URN.
+//
+// RFC8141 - 2. URN Syntax
+//
+//	namestring    = assigned-name
+//	                [ rq-components ]
+//	                [ "#" f-component ]
+//	assigned-name = "urn" ":" NID ":" NSS
+//	NID           = (alphanum) 0*30(ldh) (alphanum)
+//	ldh           = alphanum / "-"
+//	NSS           = pchar *(pchar / "/")
+//	rq-components = [ "?+" r-component ]
+//	                [ "?=" q-component ]
+//	r-component   = pchar *( pchar / "/" / "?" )
+//	q-component   = pchar *( pchar / "/" / "?" )
+//	f-component   = fragment
+//
+// NOTE
+// Parse takes "?+" and "?=" as the start of query. So the query is split into
+// r-component and q-component. "?=" in r-component is taken as the start of
+// q-component.
+func ParseUrn(data []byte) (*Urn, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("urn")) {
+		return nil, errUrnSchemeNotFound
+	}
+	if len(uri.DoubleSlash) > 0 {
+		return nil, errUrnAuthority
+	}
+
+	nid, nss, found := bytes.Cut(uri.Path, []byte(":"))
+	if !found || !isNid(nid) {
+		return nil, errNidNotFound
+	}
+	if len(nss) == 0 || nss[0] == '/' {
+		return nil, errNssNotFound
+	}
+	urn := &Urn{
+		Nid:        string(nid),
+		Nss:        string(nss),
+		FComponent: string(uri.Fragment),
+	}
+
+	if len(uri.Question) > 0 {
+		rq := string(uri.Query)
+		switch {
+		case strings.HasPrefix(rq, "+"):
+			r, q, found := strings.Cut(rq[1:], "?=")
+			if r == "" || (found && q == "") {
+				return nil, errRqComponents
+			}
+			urn.RComponent = r
+			urn.QComponent = q
+		case strings.HasPrefix(rq, "=") && len(rq) > 1:
+			urn.QComponent = rq[1:]
+		default:
+			return nil, errRqComponents
+		}
+	}
+	return urn, nil
+}
+
+// AssignedName returns "urn:" NID ":" NSS normalized as RFC8141 - 3.1 does.
+// The "urn" and NID are lowercased, and the hexadecimal digits of the
+// pct-encoded in NSS are uppercased. The rest of NSS is case-sensitive.
+func (urn *Urn) AssignedName() string {
+	return "urn:" + strings.ToLower(urn.Nid) + ":" + string(normalizePctEncoded([]byte(urn.Nss)))
+}
+
+// Equivalent reports whether urn and other are URN-equivalent as
+// RFC8141 - 3. URN-Equivalence says. r-component, q-component and f-component
+// are not taken into account.
+func (urn *Urn) Equivalent(other *Urn) bool {
+	return urn.AssignedName() == other.AssignedName()
+}
+
+func (urn *Urn) String() string {
+	str := "urn:" + urn.Nid + ":" + urn.Nss
+	if urn.RComponent != "" {
+		str += "?+" + urn.RComponent
+	}
+	if urn.QComponent != "" {
+		str += "?=" + urn.QComponent
+	}
+	if urn.FComponent != "" {
+		str += "#" + urn.FComponent
+	}
+	return str
+}
+
+func isNid(nid []byte) bool {
+	if len(nid) < 2 || len(nid) > 32 {
+		return false
+	}
+	for i, c := range nid {
+		if isAlpha(c) || isDigit(c) {
+			continue
+		}
+		if c != '-' || i == 0 || i == len(nid)-1 {
+			return false
+		}
+	}
+	return true
+}
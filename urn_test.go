@@ -0,0 +1,158 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseUrn(t *testing.T) {
+	type TestCase struct {
+		testName    string
+		data        []byte
+		expectedUrn Urn
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{
+			testName:    "data: []byte(\"urn:isbn:0451450523\")",
+			data:        []byte("urn:isbn:0451450523"),
+			expectedUrn: Urn{Nid: "isbn", Nss: "0451450523"},
+		},
+		{
+			testName:    "data: []byte(\"urn:ietf:rfc:2648\")",
+			data:        []byte("urn:ietf:rfc:2648"),
+			expectedUrn: Urn{Nid: "ietf", Nss: "rfc:2648"},
+		},
+		{
+			testName:    "data: []byte(\"URN:example:a/b%2Cc\")",
+			data:        []byte("URN:example:a/b%2Cc"),
+			expectedUrn: Urn{Nid: "example", Nss: "a/b%2Cc"},
+		},
+		// RFC8141 - 2.3.1. r-component
+		{
+			testName:    "data: []byte(\"urn:example:foo-bar-baz-qux?+CCResolve:cc=uk\")",
+			data:        []byte("urn:example:foo-bar-baz-qux?+CCResolve:cc=uk"),
+			expectedUrn: Urn{Nid: "example", Nss: "foo-bar-baz-qux", RComponent: "CCResolve:cc=uk"},
+		},
+		// RFC8141 - 2.3.2. q-component
+		{
+			testName:    "data: []byte(\"urn:example:weather?=op=map&lat=39.56&lon=-104.85\")",
+			data:        []byte("urn:example:weather?=op=map&lat=39.56&lon=-104.85"),
+			expectedUrn: Urn{Nid: "example", Nss: "weather", QComponent: "op=map&lat=39.56&lon=-104.85"},
+		},
+		{
+			testName:    "data: []byte(\"urn:example:a?+r?x?=q?y#f\")",
+			data:        []byte("urn:example:a?+r?x?=q?y#f"),
+			expectedUrn: Urn{Nid: "example", Nss: "a", RComponent: "r?x", QComponent: "q?y", FComponent: "f"},
+		},
+		// RFC8141 - 2.3.3. f-component
+		{
+			testName:    "data: []byte(\"urn:example:a123,z456#789\")",
+			data:        []byte("urn:example:a123,z456#789"),
+			expectedUrn: Urn{Nid: "example", Nss: "a123,z456", FComponent: "789"},
+		},
+		{
+			testName:    "data: []byte(\"http://example.com\")",
+			data:        []byte("http://example.com"),
+			expectedErr: errUrnSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"urn://example/a\")",
+			data:        []byte("urn://example/a"),
+			expectedErr: errUrnAuthority,
+		},
+		{
+			testName:    "data: []byte(\"urn:a:b\")",
+			data:        []byte("urn:a:b"),
+			expectedErr: errNidNotFound,
+		},
+		{
+			testName:    "data: []byte(\"urn:-ab:b\")",
+			data:        []byte("urn:-ab:b"),
+			expectedErr: errNidNotFound,
+		},
+		{
+			testName:    "data: []byte(\"urn:example\")",
+			data:        []byte("urn:example"),
+			expectedErr: errNidNotFound,
+		},
+		{
+			testName:    "data: []byte(\"urn:example:\")",
+			data:        []byte("urn:example:"),
+			expectedErr: errNssNotFound,
+		},
+		{
+			testName:    "data: []byte(\"urn:example:/a\")",
+			data:        []byte("urn:example:/a"),
+			expectedErr: errNssNotFound,
+		},
+		{
+			testName:    "data: []byte(\"urn:example:a?q\")",
+			data:        []byte("urn:example:a?q"),
+			expectedErr: errRqComponents,
+		},
+		{
+			testName:    "data: []byte(\"urn:example:a?+\")",
+			data:        []byte("urn:example:a?+"),
+			expectedErr: errRqComponents,
+		},
+		{
+			testName:    "data: []byte(\"urn:example:a?+r?=\")",
+			data:        []byte("urn:example:a?+r?="),
+			expectedErr: errRqComponents,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			urn, err := ParseUrn(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName, t, testCase.expectedUrn, *urn)
+			equals(testCase.testName+"(String)", t, string(testCase.data[4:]), urn.String()[4:])
+		})
+	}
+}
+
+func TestUrnEquivalent(t *testing.T) {
+	type TestCase struct {
+		data1    string
+		data2    string
+		expected bool
+	}
+
+	// RFC8141 - 3.2. Examples
+	tests := []TestCase{
+		{data1: "urn:example:a123,z456", data2: "URN:example:a123,z456", expected: true},
+		{data1: "urn:example:a123,z456", data2: "urn:EXAMPLE:a123,z456", expected: true},
+		{data1: "urn:example:a123,z456", data2: "urn:example:a123,z456?+abc", expected: true},
+		{data1: "urn:example:a123,z456", data2: "urn:example:a123,z456?=xyz", expected: true},
+		{data1: "urn:example:a123,z456", data2: "urn:example:a123,z456#789", expected: true},
+		{data1: "urn:example:a123,z456", data2: "urn:example:a123,z456/foo", expected: false},
+		{data1: "urn:example:a123,z456", data2: "urn:example:A123,z456", expected: false},
+		{data1: "urn:example:a123,z456", data2: "urn:example:a123%2Cz456", expected: false},
+		{data1: "urn:example:a123%2cz456", data2: "urn:example:a123%2Cz456", expected: true},
+	}
+
+	for _, testCase := range tests {
+		testName := fmt.Sprintf("%v, %v", testCase.data1, testCase.data2)
+		t.Run(testName, func(t *testing.T) {
+			urn1, err := ParseUrn([]byte(testCase.data1))
+			if err != nil {
+				t.Errorf("Failed to parse urn: %v", err.Error())
+				return
+			}
+			urn2, err := ParseUrn([]byte(testCase.data2))
+			if err != nil {
+				t.Errorf("Failed to parse urn: %v", err.Error())
+				return
+			}
+			equals(testName, t, testCase.expected, urn1.Equivalent(urn2))
+		})
+	}
+	urn, _ := ParseUrn([]byte("URN:Example:a%2cb?+r#f"))
+	equals("AssignedName", t, "urn:example:a%2Cb", urn.AssignedName())
+}
@@ -0,0 +1,108 @@
+package urip
+
+import (
+	"bytes"
+	"errors"
+)
+
+var (
+	errWebSocketSchemeNotFound = errors.New("ws or wss scheme not found.")
+	errWebSocketHostNotFound   = errors.New("host of WebSocket URI not found.")
+	errWebSocketUserInfo       = errors.New("WebSocket URI cannot have userinfo.")
+	errWebSocketFragment       = errors.New("WebSocket URI cannot have fragment.")
+)
+
+// WebSocketUri is a ws or wss URI.
+type WebSocketUri struct {
+	Uri
+}
+
+// ParseWebSocketUri parses a ws or wss URI.
+//
+// RFC6455 - 3. WebSocket URIs
+//
+//	ws-URI = "ws:" "//" host [ ":" port ] path [ "?" query ]
+//	wss-URI = "wss:" "//" host [ ":" port ] path [ "?" query ]
+//
+// Fragment identifiers are meaningless in the context of WebSocket URIs and
+// MUST NOT be used on these URIs.
+func ParseWebSocketUri(data []byte) (*WebSocketUri, error) {
+	uri, err := parseEntire(data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.EqualFold(uri.Scheme, []byte("ws")) && !bytes.EqualFold(uri.Scheme, []byte("wss")) {
+		return nil, errWebSocketSchemeNotFound
+	}
+	if err := validateWebSocketUri(uri); err != nil {
+		return nil, err
+	}
+	return &WebSocketUri{Uri: *uri}, nil
+}
+
+// WebSocketUriFromHttp converts an http or https URI to a ws or wss URI.
+// The URI is validated as a WebSocket URI, so a fragment is not allowed.
+func WebSocketUriFromHttp(uri *Uri) (*WebSocketUri, error) {
+	ws := &WebSocketUri{Uri: *uri}
+	switch {
+	case bytes.EqualFold(uri.Scheme, []byte("http")):
+		ws.Scheme = []byte("ws")
+	case bytes.EqualFold(uri.Scheme, []byte("https")):
+		ws.Scheme = []byte("wss")
+	default:
+		return nil, errHttpSchemeNotFound
+	}
+	if err := validateWebSocketUri(&ws.Uri); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// HttpUri converts the ws or wss URI to an http or https URI, such as the one
+// the opening handshake is sent to.
+func (ws *WebSocketUri) HttpUri() *HttpUri {
+	uri := &HttpUri{Uri: ws.Uri}
+	uri.Scheme = []byte("http")
+	if ws.Secure() {
+		uri.Scheme = []byte("https")
+	}
+	return uri
+}
+
+// Secure reports whether the scheme is wss.
+func (ws *WebSocketUri) Secure() bool {
+	return bytes.EqualFold(ws.Scheme, []byte("wss"))
+}
+
+// ResourceName returns the /resource name/ of the opening handshake.
+//
+// RFC6455 - 3. WebSocket URIs
+// The /resource name/ can be constructed by concatenating the following:
+//
+//   - "/" if the path component is empty
+//   - the path component
+//   - "?" if the query component is non-empty
+//   - the query component
+func (ws *WebSocketUri) ResourceName() string {
+	name := string(ws.Path)
+	if name == "" {
+		name = "/"
+	}
+	if len(ws.Query) > 0 {
+		name += "?" + string(ws.Query)
+	}
+	return name
+}
+
+func validateWebSocketUri(uri *Uri) error {
+	if len(uri.DoubleSlash) == 0 || len(uri.Host) == 0 {
+		return errWebSocketHostNotFound
+	}
+	if len(uri.AtSign) > 0 {
+		return errWebSocketUserInfo
+	}
+	if len(uri.Sharp) > 0 {
+		return errWebSocketFragment
+	}
+	return nil
+}
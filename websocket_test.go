@@ -0,0 +1,129 @@
+package urip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseWebSocketUri(t *testing.T) {
+	type TestCase struct {
+		testName             string
+		data                 []byte
+		expectedSecure       bool
+		expectedPort         string
+		expectedResourceName string
+		expectedHttpUri      string
+		expectedErr          error
+	}
+
+	tests := []TestCase{
+		{
+			testName:             "data: []byte(\"ws://example.com\")",
+			data:                 []byte("ws://example.com"),
+			expectedPort:         "80",
+			expectedResourceName: "/",
+			expectedHttpUri:      "http://example.com",
+		},
+		{
+			testName:             "data: []byte(\"wss://example.com/chat?room=1\")",
+			data:                 []byte("wss://example.com/chat?room=1"),
+			expectedSecure:       true,
+			expectedPort:         "443",
+			expectedResourceName: "/chat?room=1",
+			expectedHttpUri:      "https://example.com/chat?room=1",
+		},
+		{
+			testName:             "data: []byte(\"wss://10.0.0.1.nip.io/\")",
+			data:                 []byte("wss://10.0.0.1.nip.io/"),
+			expectedSecure:       true,
+			expectedPort:         "443",
+			expectedResourceName: "/",
+			expectedHttpUri:      "https://10.0.0.1.nip.io/",
+		},
+		{
+			testName:             "data: []byte(\"WS://[::1]:8080/a/b?\")",
+			data:                 []byte("WS://[::1]:8080/a/b?"),
+			expectedPort:         "8080",
+			expectedResourceName: "/a/b",
+			expectedHttpUri:      "http://[::1]:8080/a/b?",
+		},
+		{
+			testName:    "data: []byte(\"http://example.com\")",
+			data:        []byte("http://example.com"),
+			expectedErr: errWebSocketSchemeNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ws:/chat\")",
+			data:        []byte("ws:/chat"),
+			expectedErr: errWebSocketHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ws:///chat\")",
+			data:        []byte("ws:///chat"),
+			expectedErr: errWebSocketHostNotFound,
+		},
+		{
+			testName:    "data: []byte(\"ws://user@example.com/\")",
+			data:        []byte("ws://user@example.com/"),
+			expectedErr: errWebSocketUserInfo,
+		},
+		{
+			testName:    "data: []byte(\"ws://example.com/chat#f\")",
+			data:        []byte("ws://example.com/chat#f"),
+			expectedErr: errWebSocketFragment,
+		},
+		{
+			testName:    "data: []byte(\"ws://example.com/chat#\")",
+			data:        []byte("ws://example.com/chat#"),
+			expectedErr: errWebSocketFragment,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.testName, func(t *testing.T) {
+			ws, err := ParseWebSocketUri(testCase.data)
+			equals(testCase.testName, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.testName+"(Secure)", t, testCase.expectedSecure, ws.Secure())
+			equals(testCase.testName+"(EffectivePort)", t, testCase.expectedPort, ws.EffectivePort())
+			equals(testCase.testName+"(ResourceName)", t, testCase.expectedResourceName, ws.ResourceName())
+			equals(testCase.testName+"(HttpUri)", t, testCase.expectedHttpUri, ws.HttpUri().String())
+			equals(testCase.testName+"(HttpUri.Secure)", t, testCase.expectedSecure, ws.HttpUri().Secure())
+			equals(testCase.testName+"(String)", t, string(testCase.data), ws.String())
+		})
+	}
+}
+
+func TestWebSocketUriFromHttp(t *testing.T) {
+	type TestCase struct {
+		data        string
+		expected    string
+		expectedErr error
+	}
+
+	tests := []TestCase{
+		{data: "http://example.com/chat", expected: "ws://example.com/chat"},
+		{data: "HTTPS://example.com:8443/?a=b", expected: "wss://example.com:8443/?a=b"},
+		{data: "ftp://example.com/", expectedErr: errHttpSchemeNotFound},
+		{data: "http://example.com/#top", expectedErr: errWebSocketFragment},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.data, func(t *testing.T) {
+			uri, err := Parse([]byte(testCase.data))
+			if err != nil {
+				t.Errorf("Failed to parse: %v", err.Error())
+				return
+			}
+			ws, err := WebSocketUriFromHttp(uri)
+			equals(testCase.data, t, fmt.Sprint(testCase.expectedErr), fmt.Sprint(err))
+			if err != nil {
+				return
+			}
+			equals(testCase.data, t, testCase.expected, ws.String())
+			equals(testCase.data+"(Scheme)", t, testCase.data[:4], string(uri.Scheme[:4]))
+		})
+	}
+}